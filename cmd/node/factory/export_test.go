@@ -0,0 +1,30 @@
+package factory
+
+import (
+	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+)
+
+func CreateShardDataPoolFromConfig(
+	config *config.Config,
+	uint64ByteSliceConverter typeConverters.Uint64ByteSliceConverter,
+) (dataRetriever.PoolsHolder, error) {
+	return createShardDataPoolFromConfig(config, uint64ByteSliceConverter)
+}
+
+func CreateMetaDataPoolFromConfig(
+	config *config.Config,
+	uint64ByteSliceConverter typeConverters.Uint64ByteSliceConverter,
+) (dataRetriever.MetaPoolsHolder, error) {
+	return createMetaDataPoolFromConfig(config, uint64ByteSliceConverter)
+}
+
+func CollectedDataPoolsErrors(err error) []error {
+	dpe, ok := err.(*dataPoolsErrors)
+	if !ok {
+		return nil
+	}
+
+	return dpe.errs
+}
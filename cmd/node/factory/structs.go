@@ -10,6 +10,7 @@ import (
 	"io"
 	"math/big"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/ElrondNetwork/elrond-go/config"
@@ -101,6 +102,29 @@ const maxTxNonceDeltaAllowed = 15000
 //TODO: Extract all others error messages from this file in some defined errors
 var ErrCreateForkDetector = errors.New("could not create fork detector")
 
+// ErrInvalidCacheSize signals that a data pool has been configured with an invalid cache size
+var ErrInvalidCacheSize = errors.New("size must be > 0")
+
+// ErrorInvalidCacheSize signals which data pool has been configured with an invalid cache size
+type ErrorInvalidCacheSize struct {
+	PoolName string
+}
+
+// NewErrorInvalidCacheSize returns a new instantiated struct
+func NewErrorInvalidCacheSize(poolName string) *ErrorInvalidCacheSize {
+	return &ErrorInvalidCacheSize{PoolName: poolName}
+}
+
+// Error returns the error as string
+func (e *ErrorInvalidCacheSize) Error() string {
+	return e.PoolName + " " + ErrInvalidCacheSize.Error()
+}
+
+// Cause returns ErrInvalidCacheSize so the error can be matched against it
+func (e *ErrorInvalidCacheSize) Cause() error {
+	return ErrInvalidCacheSize
+}
+
 // Network struct holds the network components of the Elrond protocol
 type Network struct {
 	NetMessenger p2p.Messenger
@@ -416,6 +440,7 @@ type processComponentsFactoryArgs struct {
 	genesisConfig        *sharding.Genesis
 	economicsData        *economics.EconomicsData
 	nodesConfig          *sharding.NodesSetup
+	senderRateLimiter    *config.SenderRateLimiterConfig
 	syncer               ntp.SyncTimer
 	shardCoordinator     sharding.Coordinator
 	nodesCoordinator     sharding.NodesCoordinator
@@ -432,6 +457,7 @@ func NewProcessComponentsFactoryArgs(
 	genesisConfig *sharding.Genesis,
 	economicsData *economics.EconomicsData,
 	nodesConfig *sharding.NodesSetup,
+	senderRateLimiter *config.SenderRateLimiterConfig,
 	syncer ntp.SyncTimer,
 	shardCoordinator sharding.Coordinator,
 	nodesCoordinator sharding.NodesCoordinator,
@@ -446,6 +472,7 @@ func NewProcessComponentsFactoryArgs(
 		genesisConfig:        genesisConfig,
 		economicsData:        economicsData,
 		nodesConfig:          nodesConfig,
+		senderRateLimiter:    senderRateLimiter,
 		syncer:               syncer,
 		shardCoordinator:     shardCoordinator,
 		nodesCoordinator:     nodesCoordinator,
@@ -468,6 +495,7 @@ func ProcessComponentsFactory(args *processComponentsFactoryArgs) (*Process, err
 		args.state,
 		args.network,
 		args.economicsData,
+		args.senderRateLimiter,
 	)
 	if err != nil {
 		return nil, err
@@ -1059,61 +1087,42 @@ func createShardDataPoolFromConfig(
 
 	log.Info("creatingShardDataPool from config")
 
-	txPool, err := shardedData.NewShardedData(getCacherFromConfig(config.TxDataPool))
-	if err != nil {
-		log.Info("error creating txpool")
-		return nil, err
-	}
+	errs := &dataPoolsErrors{}
 
-	uTxPool, err := shardedData.NewShardedData(getCacherFromConfig(config.UnsignedTransactionDataPool))
-	if err != nil {
-		log.Info("error creating smart contract result pool")
-		return nil, err
-	}
+	txPool, err := createShardedDataPool("TxDataPool", config.TxDataPool)
+	errs.add(err)
 
-	rewardTxPool, err := shardedData.NewShardedData(getCacherFromConfig(config.RewardTransactionDataPool))
-	if err != nil {
-		log.Info("error creating reward transaction pool")
-		return nil, err
-	}
+	uTxPool, err := createShardedDataPool("UnsignedTransactionDataPool", config.UnsignedTransactionDataPool)
+	errs.add(err)
 
-	cacherCfg := getCacherFromConfig(config.BlockHeaderDataPool)
-	hdrPool, err := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
-	if err != nil {
-		log.Info("error creating hdrpool")
-		return nil, err
-	}
+	rewardTxPool, err := createShardedDataPool("RewardTransactionDataPool", config.RewardTransactionDataPool)
+	errs.add(err)
 
-	cacherCfg = getCacherFromConfig(config.MetaBlockBodyDataPool)
-	metaBlockBody, err := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
-	if err != nil {
-		log.Info("error creating metaBlockBody")
-		return nil, err
-	}
+	hdrPool, err := createCachePool("BlockHeaderDataPool", config.BlockHeaderDataPool)
+	errs.add(err)
 
-	cacherCfg = getCacherFromConfig(config.BlockHeaderNoncesDataPool)
-	hdrNoncesCacher, err := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
-	if err != nil {
-		log.Info("error creating hdrNoncesCacher")
-		return nil, err
-	}
-	hdrNonces, err := dataPool.NewNonceSyncMapCacher(hdrNoncesCacher, uint64ByteSliceConverter)
-	if err != nil {
-		log.Info("error creating hdrNonces")
-		return nil, err
-	}
+	metaBlockBody, err := createCachePool("MetaBlockBodyDataPool", config.MetaBlockBodyDataPool)
+	errs.add(err)
 
-	cacherCfg = getCacherFromConfig(config.TxBlockBodyDataPool)
-	txBlockBody, err := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
-	if err != nil {
-		log.Info("error creating txBlockBody")
-		return nil, err
+	var hdrNonces dataRetriever.Uint64SyncMapCacher
+	hdrNoncesCacher, err := createCachePool("BlockHeaderNoncesDataPool", config.BlockHeaderNoncesDataPool)
+	errs.add(err)
+	if err == nil {
+		hdrNonces, err = dataPool.NewNonceSyncMapCacher(hdrNoncesCacher, uint64ByteSliceConverter)
+		errs.add(err)
 	}
 
-	cacherCfg = getCacherFromConfig(config.PeerBlockBodyDataPool)
-	peerChangeBlockBody, err := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
+	txBlockBody, err := createCachePool("TxBlockBodyDataPool", config.TxBlockBodyDataPool)
+	errs.add(err)
+
+	peerChangeBlockBody, err := createCachePool("PeerBlockBodyDataPool", config.PeerBlockBodyDataPool)
+	errs.add(err)
+
+	validatorInfo, err := createCachePool("ValidatorInfoDataPool", config.ValidatorInfoDataPool)
+	errs.add(err)
+
+	err = errs.combined()
 	if err != nil {
-		log.Info("error creating peerChangeBlockBody")
 		return nil, err
 	}
 
@@ -1126,6 +1135,7 @@ func createShardDataPoolFromConfig(
 		txBlockBody,
 		peerChangeBlockBody,
 		metaBlockBody,
+		validatorInfo,
 	)
 }
 
@@ -1133,51 +1143,105 @@ func createMetaDataPoolFromConfig(
 	config *config.Config,
 	uint64ByteSliceConverter typeConverters.Uint64ByteSliceConverter,
 ) (dataRetriever.MetaPoolsHolder, error) {
-	cacherCfg := getCacherFromConfig(config.MetaBlockBodyDataPool)
-	metaBlockBody, err := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
-	if err != nil {
-		log.Info("error creating metaBlockBody")
-		return nil, err
+	errs := &dataPoolsErrors{}
+
+	metaBlockBody, err := createCachePool("MetaBlockBodyDataPool", config.MetaBlockBodyDataPool)
+	errs.add(err)
+
+	txBlockBody, err := createCachePool("TxBlockBodyDataPool", config.TxBlockBodyDataPool)
+	errs.add(err)
+
+	var headersNonces dataRetriever.Uint64SyncMapCacher
+	shardHeaders, err := createCachePool("ShardHeadersDataPool", config.ShardHeadersDataPool)
+	errs.add(err)
+	if err == nil {
+		// the shard headers nonces pool shares the configuration of the shard headers pool
+		var headersNoncesCacher storage.Cacher
+		headersNoncesCacher, err = createCachePool("ShardHeadersDataPool", config.ShardHeadersDataPool)
+		errs.add(err)
+		if err == nil {
+			headersNonces, err = dataPool.NewNonceSyncMapCacher(headersNoncesCacher, uint64ByteSliceConverter)
+			errs.add(err)
+		}
 	}
 
-	cacherCfg = getCacherFromConfig(config.TxBlockBodyDataPool)
-	txBlockBody, err := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
+	txPool, err := createShardedDataPool("TxDataPool", config.TxDataPool)
+	errs.add(err)
+
+	uTxPool, err := createShardedDataPool("UnsignedTransactionDataPool", config.UnsignedTransactionDataPool)
+	errs.add(err)
+
+	err = errs.combined()
 	if err != nil {
-		log.Info("error creating txBlockBody")
 		return nil, err
 	}
 
-	cacherCfg = getCacherFromConfig(config.ShardHeadersDataPool)
-	shardHeaders, err := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
-	if err != nil {
-		log.Info("error creating shardHeaders")
-		return nil, err
+	return dataPool.NewMetaDataPool(metaBlockBody, txBlockBody, shardHeaders, headersNonces, txPool, uTxPool)
+}
+
+func createCachePool(poolName string, cfg config.CacheConfig) (storage.Cacher, error) {
+	if cfg.Size == 0 {
+		return nil, NewErrorInvalidCacheSize(poolName)
 	}
 
-	headersNoncesCacher, err := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
+	cacherCfg := getCacherFromConfig(cfg)
+	cacher, err := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 	if err != nil {
-		log.Info("error creating shard headers nonces pool")
-		return nil, err
+		return nil, errors.New("error creating " + poolName + ": " + err.Error())
 	}
-	headersNonces, err := dataPool.NewNonceSyncMapCacher(headersNoncesCacher, uint64ByteSliceConverter)
-	if err != nil {
-		log.Info("error creating shard headers nonces pool")
-		return nil, err
+
+	return cacher, nil
+}
+
+func createShardedDataPool(poolName string, cfg config.CacheConfig) (dataRetriever.ShardedDataCacherNotifier, error) {
+	if cfg.Size == 0 {
+		return nil, NewErrorInvalidCacheSize(poolName)
 	}
 
-	txPool, err := shardedData.NewShardedData(getCacherFromConfig(config.TxDataPool))
+	pool, err := shardedData.NewShardedData(getCacherFromConfig(cfg))
 	if err != nil {
-		log.Info("error creating txpool")
-		return nil, err
+		return nil, errors.New("error creating " + poolName + ": " + err.Error())
 	}
 
-	uTxPool, err := shardedData.NewShardedData(getCacherFromConfig(config.UnsignedTransactionDataPool))
-	if err != nil {
-		log.Info("error creating smart contract result pool")
-		return nil, err
+	return pool, nil
+}
+
+// dataPoolsErrors collects the errors encountered while creating the data pools so that all the
+// misconfigured pools are reported at once instead of one per run
+type dataPoolsErrors struct {
+	errs []error
+}
+
+func (dpe *dataPoolsErrors) add(err error) {
+	if err == nil {
+		return
 	}
 
-	return dataPool.NewMetaDataPool(metaBlockBody, txBlockBody, shardHeaders, headersNonces, txPool, uTxPool)
+	log.Info(err.Error())
+	dpe.errs = append(dpe.errs, err)
+}
+
+// combined returns nil if no error was collected, the error itself if only one was collected or the
+// collector, listing all the collected errors, otherwise
+func (dpe *dataPoolsErrors) combined() error {
+	switch len(dpe.errs) {
+	case 0:
+		return nil
+	case 1:
+		return dpe.errs[0]
+	}
+
+	return dpe
+}
+
+// Error returns the error as string
+func (dpe *dataPoolsErrors) Error() string {
+	messages := make([]string, len(dpe.errs))
+	for i, err := range dpe.errs {
+		messages[i] = err.Error()
+	}
+
+	return fmt.Sprintf("%d data pools could not be created: %s", len(dpe.errs), strings.Join(messages, "; "))
 }
 
 func createSingleSigner(config *config.Config) (crypto.SingleSigner, error) {
@@ -1275,6 +1339,7 @@ func newInterceptorAndResolverContainerFactory(
 	state *State,
 	network *Network,
 	economics *economics.EconomicsData,
+	senderRateLimiter *config.SenderRateLimiterConfig,
 ) (process.InterceptorsContainerFactory, dataRetriever.ResolversContainerFactory, error) {
 
 	if shardCoordinator.SelfId() < shardCoordinator.NumberOfShards() {
@@ -1287,6 +1352,7 @@ func newInterceptorAndResolverContainerFactory(
 			state,
 			network,
 			economics,
+			senderRateLimiter,
 		)
 	}
 	if shardCoordinator.SelfId() == sharding.MetachainShardId {
@@ -1299,6 +1365,7 @@ func newInterceptorAndResolverContainerFactory(
 			network,
 			state,
 			economics,
+			senderRateLimiter,
 		)
 	}
 
@@ -1314,6 +1381,7 @@ func newShardInterceptorAndResolverContainerFactory(
 	state *State,
 	network *Network,
 	economics *economics.EconomicsData,
+	senderRateLimiter *config.SenderRateLimiterConfig,
 ) (process.InterceptorsContainerFactory, dataRetriever.ResolversContainerFactory, error) {
 
 	interceptorContainerFactory, err := shard.NewInterceptorsContainerFactory(
@@ -1336,6 +1404,13 @@ func newShardInterceptorAndResolverContainerFactory(
 		return nil, nil, err
 	}
 
+	if senderRateLimiter.RatePerSecond > 0 {
+		err = interceptorContainerFactory.SetSenderRateLimit(senderRateLimiter.RatePerSecond, senderRateLimiter.Burst)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	dataPacker, err := partitioning.NewSimpleDataPacker(core.Marshalizer)
 	if err != nil {
 		return nil, nil, err
@@ -1366,6 +1441,7 @@ func newMetaInterceptorAndResolverContainerFactory(
 	network *Network,
 	state *State,
 	economics *economics.EconomicsData,
+	senderRateLimiter *config.SenderRateLimiterConfig,
 ) (process.InterceptorsContainerFactory, dataRetriever.ResolversContainerFactory, error) {
 
 	interceptorContainerFactory, err := metachain.NewInterceptorsContainerFactory(
@@ -1388,6 +1464,13 @@ func newMetaInterceptorAndResolverContainerFactory(
 		return nil, nil, err
 	}
 
+	if senderRateLimiter.RatePerSecond > 0 {
+		err = interceptorContainerFactory.SetSenderRateLimit(senderRateLimiter.RatePerSecond, senderRateLimiter.Burst)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	dataPacker, err := partitioning.NewSimpleDataPacker(core.Marshalizer)
 	if err != nil {
 		return nil, nil, err
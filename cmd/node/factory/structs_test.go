@@ -0,0 +1,141 @@
+package factory_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/cmd/node/factory"
+	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters/uint64ByteSlice"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func createDataPoolsConfig() *config.Config {
+	lruCacheConfig := config.CacheConfig{Size: 100, Type: "LRU"}
+	shardedCacheConfig := config.CacheConfig{Size: 100, Type: "LRU", Shards: 1}
+
+	return &config.Config{
+		TxDataPool:                  shardedCacheConfig,
+		UnsignedTransactionDataPool: shardedCacheConfig,
+		RewardTransactionDataPool:   shardedCacheConfig,
+		BlockHeaderDataPool:         lruCacheConfig,
+		MetaBlockBodyDataPool:       lruCacheConfig,
+		BlockHeaderNoncesDataPool:   lruCacheConfig,
+		TxBlockBodyDataPool:         lruCacheConfig,
+		PeerBlockBodyDataPool:       lruCacheConfig,
+		ShardHeadersDataPool:        lruCacheConfig,
+		ValidatorInfoDataPool:       config.CacheConfig{Size: 50, Type: "LRU"},
+	}
+}
+
+func TestCreateShardDataPoolFromConfig_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	pools, err := factory.CreateShardDataPoolFromConfig(createDataPoolsConfig(), uint64ByteSlice.NewBigEndianConverter())
+
+	assert.Nil(t, err)
+	assert.NotNil(t, pools)
+	assert.NotNil(t, pools.ValidatorInfo())
+	assert.Equal(t, 50, pools.ValidatorInfo().MaxSize())
+}
+
+func TestCreateShardDataPoolFromConfig_ZeroCacheSizeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]func(cfg *config.Config){
+		"TxDataPool":                  func(cfg *config.Config) { cfg.TxDataPool.Size = 0 },
+		"UnsignedTransactionDataPool": func(cfg *config.Config) { cfg.UnsignedTransactionDataPool.Size = 0 },
+		"RewardTransactionDataPool":   func(cfg *config.Config) { cfg.RewardTransactionDataPool.Size = 0 },
+		"BlockHeaderDataPool":         func(cfg *config.Config) { cfg.BlockHeaderDataPool.Size = 0 },
+		"MetaBlockBodyDataPool":       func(cfg *config.Config) { cfg.MetaBlockBodyDataPool.Size = 0 },
+		"BlockHeaderNoncesDataPool":   func(cfg *config.Config) { cfg.BlockHeaderNoncesDataPool.Size = 0 },
+		"TxBlockBodyDataPool":         func(cfg *config.Config) { cfg.TxBlockBodyDataPool.Size = 0 },
+		"PeerBlockBodyDataPool":       func(cfg *config.Config) { cfg.PeerBlockBodyDataPool.Size = 0 },
+		"ValidatorInfoDataPool":       func(cfg *config.Config) { cfg.ValidatorInfoDataPool.Size = 0 },
+	}
+
+	for poolName, setZeroSize := range testCases {
+		cfg := createDataPoolsConfig()
+		setZeroSize(cfg)
+
+		pools, err := factory.CreateShardDataPoolFromConfig(cfg, uint64ByteSlice.NewBigEndianConverter())
+
+		assert.Nil(t, pools)
+		assert.Equal(t, factory.NewErrorInvalidCacheSize(poolName), err)
+		assert.Equal(t, factory.ErrInvalidCacheSize, errors.Cause(err))
+	}
+}
+
+func TestCreateShardDataPoolFromConfig_MoreFailuresShouldReportAll(t *testing.T) {
+	t.Parallel()
+
+	cfg := createDataPoolsConfig()
+	cfg.PeerBlockBodyDataPool.Size = 0
+	cfg.BlockHeaderDataPool.Type = "unknown"
+
+	pools, err := factory.CreateShardDataPoolFromConfig(cfg, uint64ByteSlice.NewBigEndianConverter())
+
+	assert.Nil(t, pools)
+	assert.True(t, strings.HasPrefix(err.Error(), "2 data pools could not be created"))
+	assert.True(t, strings.Contains(err.Error(), "error creating BlockHeaderDataPool: "+storage.ErrNotSupportedCacheType.Error()))
+
+	collectedErrs := factory.CollectedDataPoolsErrors(err)
+	assert.Equal(t, 2, len(collectedErrs))
+	assert.Equal(t, factory.NewErrorInvalidCacheSize("PeerBlockBodyDataPool"), collectedErrs[1])
+}
+
+func TestCreateMetaDataPoolFromConfig_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	pools, err := factory.CreateMetaDataPoolFromConfig(createDataPoolsConfig(), uint64ByteSlice.NewBigEndianConverter())
+
+	assert.Nil(t, err)
+	assert.NotNil(t, pools)
+}
+
+func TestCreateMetaDataPoolFromConfig_ZeroCacheSizeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]func(cfg *config.Config){
+		"MetaBlockBodyDataPool":       func(cfg *config.Config) { cfg.MetaBlockBodyDataPool.Size = 0 },
+		"TxBlockBodyDataPool":         func(cfg *config.Config) { cfg.TxBlockBodyDataPool.Size = 0 },
+		"ShardHeadersDataPool":        func(cfg *config.Config) { cfg.ShardHeadersDataPool.Size = 0 },
+		"TxDataPool":                  func(cfg *config.Config) { cfg.TxDataPool.Size = 0 },
+		"UnsignedTransactionDataPool": func(cfg *config.Config) { cfg.UnsignedTransactionDataPool.Size = 0 },
+	}
+
+	for poolName, setZeroSize := range testCases {
+		cfg := createDataPoolsConfig()
+		setZeroSize(cfg)
+
+		pools, err := factory.CreateMetaDataPoolFromConfig(cfg, uint64ByteSlice.NewBigEndianConverter())
+
+		assert.Nil(t, pools)
+		assert.Equal(t, factory.NewErrorInvalidCacheSize(poolName), err)
+		assert.Equal(t, factory.ErrInvalidCacheSize, errors.Cause(err))
+	}
+}
+
+func TestCreateMetaDataPoolFromConfig_MoreFailuresShouldReportAll(t *testing.T) {
+	t.Parallel()
+
+	cfg := createDataPoolsConfig()
+	cfg.ShardHeadersDataPool.Size = 0
+	cfg.TxDataPool.Size = 0
+	cfg.UnsignedTransactionDataPool.Size = 0
+
+	pools, err := factory.CreateMetaDataPoolFromConfig(cfg, uint64ByteSlice.NewBigEndianConverter())
+
+	assert.Nil(t, pools)
+	assert.True(t, strings.HasPrefix(err.Error(), "3 data pools could not be created"))
+	assert.Equal(t,
+		[]error{
+			factory.NewErrorInvalidCacheSize("ShardHeadersDataPool"),
+			factory.NewErrorInvalidCacheSize("TxDataPool"),
+			factory.NewErrorInvalidCacheSize("UnsignedTransactionDataPool"),
+		},
+		factory.CollectedDataPoolsErrors(err),
+	)
+}
@@ -668,6 +668,7 @@ func startNode(ctx *cli.Context, log *logger.Logger, version string) error {
 		genesisConfig,
 		economicsData,
 		nodesConfig,
+		&generalConfig.SenderRateLimiter,
 		syncer,
 		shardCoordinator,
 		nodesCoordinator,
@@ -1162,7 +1163,7 @@ func initLogFileAndStatsMonitor(config *config.Config, pubKey crypto.PublicKey,
 	if err != nil {
 		return err
 	}
-	err = startStatisticsMonitor(statsFile, config.ResourceStats, log)
+	err = startStatisticsMonitor(statsFile, filepath.Join(workingDir, defaultDBPath), config.ResourceStats)
 	if err != nil {
 		return err
 	}
@@ -1190,7 +1191,11 @@ func setServiceContainer(shardCoordinator sharding.Coordinator, tpsBenchmark *st
 	return errors.New("could not init core service container")
 }
 
-func startStatisticsMonitor(file *os.File, config config.ResourceStatsConfig, log *logger.Logger) error {
+func startStatisticsMonitor(
+	file *os.File,
+	dataPath string,
+	config config.ResourceStatsConfig,
+) error {
 	if !config.Enabled {
 		return nil
 	}
@@ -1199,18 +1204,15 @@ func startStatisticsMonitor(file *os.File, config config.ResourceStatsConfig, lo
 		return errors.New("invalid RefreshIntervalInSec in section [ResourceStats]. Should be an integer higher than 1")
 	}
 
-	rm, err := statistics.NewResourceMonitor(file)
+	rm, err := statistics.NewResourceMonitor(file, dataPath)
 	if err != nil {
 		return err
 	}
 
-	go func() {
-		for {
-			err = rm.SaveStatistics()
-			log.LogIfError(err)
-			time.Sleep(time.Second * time.Duration(config.RefreshIntervalInSec))
-		}
-	}()
+	err = rm.StartMonitoring(time.Second * time.Duration(config.RefreshIntervalInSec))
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -83,6 +83,7 @@ type Config struct {
 	UnsignedTransactionDataPool CacheConfig
 	RewardTransactionDataPool   CacheConfig
 	MetaBlockBodyDataPool       CacheConfig
+	ValidatorInfoDataPool       CacheConfig
 
 	MiniBlockHeaderHashesDataPool CacheConfig
 	ShardHeadersDataPool          CacheConfig
@@ -94,11 +95,12 @@ type Config struct {
 	MultisigHasher TypeConfig
 	Marshalizer    TypeConfig
 
-	ResourceStats   ResourceStatsConfig
-	Heartbeat       HeartbeatConfig
-	GeneralSettings GeneralSettingsConfig
-	Consensus       TypeConfig
-	Explorer        ExplorerConfig
+	ResourceStats     ResourceStatsConfig
+	Heartbeat         HeartbeatConfig
+	GeneralSettings   GeneralSettingsConfig
+	Consensus         TypeConfig
+	Explorer          ExplorerConfig
+	SenderRateLimiter SenderRateLimiterConfig
 
 	NTPConfig NTPConfig
 }
@@ -129,13 +131,23 @@ type ResourceStatsConfig struct {
 	RefreshIntervalInSec int
 }
 
+// SenderRateLimiterConfig will hold the per sender rate limiting settings of the header and miniblock interceptors
+type SenderRateLimiterConfig struct {
+	RatePerSecond float64
+	Burst         uint32
+}
+
 // HeartbeatConfig will hold all heartbeat settings
 type HeartbeatConfig struct {
-	Enabled                             bool
-	MinTimeToWaitBetweenBroadcastsInSec int
-	MaxTimeToWaitBetweenBroadcastsInSec int
-	DurationInSecToConsiderUnresponsive int
-	HeartbeatStorage                    StorageConfig
+	Enabled                                      bool
+	MinTimeToWaitBetweenBroadcastsInSec          int
+	MaxTimeToWaitBetweenBroadcastsInSec          int
+	DurationInSecToConsiderUnresponsive          int
+	DurationInSecToConsiderValidatorUnresponsive int
+	GracePeriodInSec                             int
+	SettlePeriodInSec                            int
+	MaxAllowedTimeSkewInSec                      int
+	HeartbeatStorage                             StorageConfig
 }
 
 // GeneralSettingsConfig will hold the general settings for a node
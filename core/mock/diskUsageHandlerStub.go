@@ -0,0 +1,16 @@
+package mock
+
+type DiskUsageHandlerStub struct {
+	FreeBytesCalled func(path string) (uint64, error)
+}
+
+func (duhs *DiskUsageHandlerStub) FreeBytes(path string) (uint64, error) {
+	return duhs.FreeBytesCalled(path)
+}
+
+func (duhs *DiskUsageHandlerStub) IsInterfaceNil() bool {
+	if duhs == nil {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package mock
+
+type ProcessIOHandlerStub struct {
+	IOCountersCalled func() (uint64, uint64, error)
+}
+
+func (piohs *ProcessIOHandlerStub) IOCounters() (uint64, uint64, error) {
+	return piohs.IOCountersCalled()
+}
+
+func (piohs *ProcessIOHandlerStub) IsInterfaceNil() bool {
+	if piohs == nil {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+package mock
+
+type ProcessLimitsHandlerStub struct {
+	NumThreadsCalled           func() (int32, error)
+	FileDescriptorsLimitCalled func() (uint64, error)
+}
+
+func (plhs *ProcessLimitsHandlerStub) NumThreads() (int32, error) {
+	return plhs.NumThreadsCalled()
+}
+
+func (plhs *ProcessLimitsHandlerStub) FileDescriptorsLimit() (uint64, error) {
+	return plhs.FileDescriptorsLimitCalled()
+}
+
+func (plhs *ProcessLimitsHandlerStub) IsInterfaceNil() bool {
+	if plhs == nil {
+		return true
+	}
+	return false
+}
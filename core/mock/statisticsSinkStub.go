@@ -0,0 +1,16 @@
+package mock
+
+type StatisticsSinkStub struct {
+	PushCalled func(metrics map[string]interface{})
+}
+
+func (sss *StatisticsSinkStub) Push(metrics map[string]interface{}) {
+	sss.PushCalled(metrics)
+}
+
+func (sss *StatisticsSinkStub) IsInterfaceNil() bool {
+	if sss == nil {
+		return true
+	}
+	return false
+}
@@ -12,3 +12,24 @@ var ErrInvalidRoundDuration = errors.New("invalid round duration")
 
 // ErrNilFileToWriteStats signals that the file where statistics should be written is nil
 var ErrNilFileToWriteStats = errors.New("nil file to write statistics")
+
+// ErrInvalidMonitoringInterval signals that a zero or negative monitoring interval was provided
+var ErrInvalidMonitoringInterval = errors.New("invalid monitoring interval, should be greater than zero")
+
+// ErrMonitoringAlreadyStarted signals that the monitoring loop was already started
+var ErrMonitoringAlreadyStarted = errors.New("monitoring already started")
+
+// ErrNilDiskUsageHandler signals that a nil disk usage handler was provided
+var ErrNilDiskUsageHandler = errors.New("nil disk usage handler")
+
+// ErrEmptyDiskPath signals that an empty disk path was provided
+var ErrEmptyDiskPath = errors.New("empty disk path")
+
+// ErrInvalidDumpThresholds signals that negative goroutines dump thresholds were provided
+var ErrInvalidDumpThresholds = errors.New("invalid goroutines dump thresholds, should not be negative")
+
+// ErrInvalidMaxRotatedFiles signals that a negative number of rotated files to keep was provided
+var ErrInvalidMaxRotatedFiles = errors.New("invalid number of rotated files to keep, should not be negative")
+
+// ErrNilStatisticsSink signals that a nil statistics sink was provided
+var ErrNilStatisticsSink = errors.New("nil statistics sink")
@@ -0,0 +1,27 @@
+package statistics
+
+import (
+	"github.com/shirou/gopsutil/net"
+)
+
+func (rm *ResourceMonitor) SetProcessIOHandler(processIO ProcessIOHandler) {
+	rm.mutConfig.Lock()
+	rm.processIO = processIO
+	rm.mutConfig.Unlock()
+}
+
+func (rm *ResourceMonitor) SetDiskUsageHandler(diskUsage DiskUsageHandler) {
+	rm.mutConfig.Lock()
+	rm.diskUsage = diskUsage
+	rm.mutConfig.Unlock()
+}
+
+func FormatConnectionsByStatus(conns []net.ConnectionStat) string {
+	return formatConnectionsByStatus(conns)
+}
+
+func (rm *ResourceMonitor) SetProcessLimitsHandler(processLimits ProcessLimitsHandler) {
+	rm.mutConfig.Lock()
+	rm.processLimits = processLimits
+	rm.mutConfig.Unlock()
+}
@@ -36,3 +36,29 @@ type ShardStatistic interface {
 	TotalProcessedTxCount() *big.Int
 	IsInterfaceNil() bool
 }
+
+// DiskUsageHandler defines the source used to fetch the free disk space of the partition holding a path
+type DiskUsageHandler interface {
+	FreeBytes(path string) (uint64, error)
+	IsInterfaceNil() bool
+}
+
+// ProcessIOHandler defines the source used to fetch the number of bytes read and written by the current process
+type ProcessIOHandler interface {
+	IOCounters() (readBytes uint64, writeBytes uint64, err error)
+	IsInterfaceNil() bool
+}
+
+// StatisticsSink defines a destination the resource statistics are pushed to on each save, such as a metrics exporter
+type StatisticsSink interface {
+	Push(metrics map[string]interface{})
+	IsInterfaceNil() bool
+}
+
+// ProcessLimitsHandler defines the source used to fetch the number of threads and the open file descriptors limit
+// of the current process
+type ProcessLimitsHandler interface {
+	NumThreads() (int32, error)
+	FileDescriptorsLimit() (uint64, error)
+	IsInterfaceNil() bool
+}
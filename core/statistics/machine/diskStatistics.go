@@ -0,0 +1,27 @@
+package machine
+
+import (
+	"github.com/shirou/gopsutil/disk"
+)
+
+// DiskUsage can fetch the disk usage of the partition holding a given path
+type DiskUsage struct {
+}
+
+// FreeBytes returns the number of free bytes on the partition holding the provided path
+func (du *DiskUsage) FreeBytes(path string) (uint64, error) {
+	usage, err := disk.Usage(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return usage.Free, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (du *DiskUsage) IsInterfaceNil() bool {
+	if du == nil {
+		return true
+	}
+	return false
+}
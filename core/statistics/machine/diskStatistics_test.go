@@ -0,0 +1,27 @@
+package machine
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskUsage_FreeBytesShouldWork(t *testing.T) {
+	t.Parallel()
+
+	du := &DiskUsage{}
+	free, err := du.FreeBytes(os.TempDir())
+
+	assert.Nil(t, err)
+	assert.True(t, free > 0)
+}
+
+func TestDiskUsage_FreeBytesInvalidPathShouldErr(t *testing.T) {
+	t.Parallel()
+
+	du := &DiskUsage{}
+	_, err := du.FreeBytes("/this/path/should/not/exist")
+
+	assert.NotNil(t, err)
+}
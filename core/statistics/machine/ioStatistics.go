@@ -0,0 +1,29 @@
+package machine
+
+// ProcessIO can fetch the number of bytes read from and written to the disk by the current process
+type ProcessIO struct {
+}
+
+// IOCounters returns the number of bytes read and written by the current process. An error is returned
+// on platforms where the counters are not available
+func (pio *ProcessIO) IOCounters() (uint64, uint64, error) {
+	proc, err := GetCurrentProcess()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	counters, err := proc.IOCounters()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return counters.ReadBytes, counters.WriteBytes, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (pio *ProcessIO) IsInterfaceNil() bool {
+	if pio == nil {
+		return true
+	}
+	return false
+}
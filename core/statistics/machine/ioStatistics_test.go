@@ -0,0 +1,36 @@
+package machine
+
+import (
+	"io/ioutil"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessIO_IOCountersShouldWork(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("process IO counters are only checked on linux")
+	}
+
+	pio := &ProcessIO{}
+	_, writeBytesBefore, err := pio.IOCounters()
+	assert.Nil(t, err)
+
+	file, err := ioutil.TempFile("", "ioStatistics")
+	assert.Nil(t, err)
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}()
+
+	_, err = file.Write(make([]byte, 1024*1024))
+	assert.Nil(t, err)
+	err = file.Sync()
+	assert.Nil(t, err)
+
+	_, writeBytesAfter, err := pio.IOCounters()
+	assert.Nil(t, err)
+	assert.True(t, writeBytesAfter >= writeBytesBefore)
+}
@@ -0,0 +1,59 @@
+package machine
+
+import (
+	"errors"
+
+	"github.com/shirou/gopsutil/process"
+)
+
+// ErrFileDescriptorsLimitNotAvailable signals that the open file descriptors limit could not be fetched
+var ErrFileDescriptorsLimitNotAvailable = errors.New("open file descriptors limit not available")
+
+// ProcessLimits can fetch the number of threads and the open file descriptors limit of the current process
+type ProcessLimits struct {
+}
+
+// NumThreads returns the number of OS threads used by the current process
+func (pl *ProcessLimits) NumThreads() (int32, error) {
+	proc, err := GetCurrentProcess()
+	if err != nil {
+		return 0, err
+	}
+
+	return proc.NumThreads()
+}
+
+// FileDescriptorsLimit returns the soft limit of the open file descriptors (RLIMIT_NOFILE) of the current process.
+// An error is returned on platforms where the limit is not available or if the limit is not set
+func (pl *ProcessLimits) FileDescriptorsLimit() (uint64, error) {
+	proc, err := GetCurrentProcess()
+	if err != nil {
+		return 0, err
+	}
+
+	limits, err := proc.Rlimit()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, limit := range limits {
+		if limit.Resource != process.RLIMIT_NOFILE {
+			continue
+		}
+		if limit.Soft <= 0 {
+			return 0, ErrFileDescriptorsLimitNotAvailable
+		}
+
+		return uint64(limit.Soft), nil
+	}
+
+	return 0, ErrFileDescriptorsLimitNotAvailable
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (pl *ProcessLimits) IsInterfaceNil() bool {
+	if pl == nil {
+		return true
+	}
+	return false
+}
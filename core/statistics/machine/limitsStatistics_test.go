@@ -0,0 +1,35 @@
+package machine
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessLimits_NumThreadsShouldWork(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("process threads are only checked on linux")
+	}
+
+	pl := &ProcessLimits{}
+	numThreads, err := pl.NumThreads()
+
+	assert.Nil(t, err)
+	assert.True(t, numThreads > 0)
+}
+
+func TestProcessLimits_FileDescriptorsLimitShouldWork(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("open file descriptors limit is only checked on linux")
+	}
+
+	pl := &ProcessLimits{}
+	limit, err := pl.FileDescriptorsLimit()
+	if err == ErrFileDescriptorsLimitNotAvailable {
+		t.Skip("open file descriptors limit is not set")
+	}
+
+	assert.Nil(t, err)
+	assert.True(t, limit > 0)
+}
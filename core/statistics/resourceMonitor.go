@@ -2,42 +2,260 @@ package statistics
 
 import (
 	"fmt"
+	"hash/crc32"
 	"os"
+	"path/filepath"
 	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/logger"
 	"github.com/ElrondNetwork/elrond-go/core/statistics/machine"
+	"github.com/shirou/gopsutil/net"
 )
 
+var log = logger.DefaultLogger()
+
+// statsChannelSize is the number of statistics lines buffered for in-process consumers
+const statsChannelSize = 100
+
+// rotatedFileTimeFormat is the format of the timestamp appended to the name of a rotated statistics file.
+// It sorts lexicographically in chronological order
+const rotatedFileTimeFormat = "20060102-150405.000000000"
+
+// DefaultMinMonitoringInterval is the minimum interval between two statistics samples used when none is configured.
+// Intervals lower than the minimum are raised to it so that the monitoring loop can not spin
+const DefaultMinMonitoringInterval = time.Second
+
 // ResourceMonitor outputs statistics about resources used by the binary
 type ResourceMonitor struct {
-	startTime time.Time
-	file      *os.File
-	mutFile   sync.RWMutex
+	startTime             time.Time
+	file                  *os.File
+	mutFile               sync.RWMutex
+	statsChan             chan string
+	sinks                 []StatisticsSink
+	isClosed              bool
+	minMonitoringInterval time.Duration
+	chStopMonitoring      chan struct{}
+	chMonitoringDone      chan struct{}
+	mutConfig             sync.RWMutex
+	outputRawBytes        bool
+	diskPath              string
+	minFreeDiskBytes      uint64
+	diskUsage             DiskUsageHandler
+	dataPath              string
+	processIO             ProcessIOHandler
+	processLimits         ProcessLimitsHandler
+	dumpMaxGoroutines     int
+	dumpMaxHeapBytes      uint64
+	dumpCoolDown          time.Duration
+	dumpFilePrefix        string
+	mutDump               sync.Mutex
+	lastDumpTime          time.Time
+	maxFileBytes          uint64
+	maxRotatedFiles       int
+	checksumFooter        bool
+	mutChecksum           sync.Mutex
+	checksum              uint32
+	numWrittenLines       uint64
 }
 
-// NewResourceMonitor creates a new ResourceMonitor instance
-func NewResourceMonitor(file *os.File) (*ResourceMonitor, error) {
-	if file == nil {
+// NewResourceMonitor creates a new ResourceMonitor instance. The free disk space reported is the one of the partition
+// holding the provided data path. If the data path is empty, the free disk space is not reported. The statistics
+// are written in the provided file and pushed to all the provided sinks. The file can be nil if at least one
+// sink is provided
+func NewResourceMonitor(file *os.File, dataPath string, sinks ...StatisticsSink) (*ResourceMonitor, error) {
+	if file == nil && len(sinks) == 0 {
 		return nil, ErrNilFileToWriteStats
 	}
+	for _, sink := range sinks {
+		if sink == nil || sink.IsInterfaceNil() {
+			return nil, ErrNilStatisticsSink
+		}
+	}
 
 	return &ResourceMonitor{
-		startTime: time.Now(),
-		file:      file,
+		sinks:                 sinks,
+		startTime:             time.Now(),
+		file:                  file,
+		minMonitoringInterval: DefaultMinMonitoringInterval,
+		dataPath:              dataPath,
+		diskUsage:             &machine.DiskUsage{},
+		processIO:             &machine.ProcessIO{},
+		processLimits:         &machine.ProcessLimits{},
 	}, nil
 }
 
+// SetOutputRawBytes enables or disables the output of the raw number of bytes next to each
+// human readable memory figure, as in "alloc: 1.00 MB (1048576)"
+func (rm *ResourceMonitor) SetOutputRawBytes(enabled bool) {
+	rm.mutConfig.Lock()
+	rm.outputRawBytes = enabled
+	rm.mutConfig.Unlock()
+}
+
+// SetMinFreeDiskCheck enables a check done before each statistics write: if the partition holding the provided
+// path has less than minFreeDiskBytes free bytes, the write is skipped so the monitor does not help filling the disk
+func (rm *ResourceMonitor) SetMinFreeDiskCheck(path string, minFreeDiskBytes uint64, diskUsage DiskUsageHandler) error {
+	if len(path) == 0 {
+		return ErrEmptyDiskPath
+	}
+	if diskUsage == nil || diskUsage.IsInterfaceNil() {
+		return ErrNilDiskUsageHandler
+	}
+
+	rm.mutConfig.Lock()
+	rm.diskPath = path
+	rm.minFreeDiskBytes = minFreeDiskBytes
+	rm.diskUsage = diskUsage
+	rm.mutConfig.Unlock()
+
+	return nil
+}
+
+// SetChecksumFooter enables or disables the footer written on Close. The footer holds the CRC32 (IEEE) checksum
+// and the number of the statistics lines written by this monitor so that tools can detect truncated files
+func (rm *ResourceMonitor) SetChecksumFooter(enabled bool) {
+	rm.mutConfig.Lock()
+	rm.checksumFooter = enabled
+	rm.mutConfig.Unlock()
+}
+
+// SetGoroutinesDumpThresholds enables writing a goroutines dump next to the statistics file each time the number
+// of goroutines or the allocated heap bytes observed while saving the statistics exceed the provided thresholds.
+// A zero threshold is not checked. At most one dump is written during each cool down interval
+func (rm *ResourceMonitor) SetGoroutinesDumpThresholds(maxGoroutines int, maxHeapBytes uint64, coolDown time.Duration) error {
+	if maxGoroutines < 0 || coolDown < 0 {
+		return ErrInvalidDumpThresholds
+	}
+
+	rm.mutFile.RLock()
+	file := rm.file
+	rm.mutFile.RUnlock()
+	if file == nil {
+		return ErrNilFileToWriteStats
+	}
+
+	fileName := file.Name()
+	rm.mutConfig.Lock()
+	rm.dumpMaxGoroutines = maxGoroutines
+	rm.dumpMaxHeapBytes = maxHeapBytes
+	rm.dumpCoolDown = coolDown
+	rm.dumpFilePrefix = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	rm.mutConfig.Unlock()
+
+	return nil
+}
+
+// SetFileRotation enables the rotation of the statistics file: once the file reaches maxFileBytes, it is renamed
+// by appending a timestamp to its name and a fresh file is opened. Only the newest maxRotatedFiles rotated files
+// are kept. A zero maxFileBytes disables the rotation
+func (rm *ResourceMonitor) SetFileRotation(maxFileBytes uint64, maxRotatedFiles int) error {
+	if maxRotatedFiles < 0 {
+		return ErrInvalidMaxRotatedFiles
+	}
+
+	rm.mutConfig.Lock()
+	rm.maxFileBytes = maxFileBytes
+	rm.maxRotatedFiles = maxRotatedFiles
+	rm.mutConfig.Unlock()
+
+	return nil
+}
+
+// SetMinMonitoringInterval sets the minimum interval between two statistics samples
+func (rm *ResourceMonitor) SetMinMonitoringInterval(minInterval time.Duration) error {
+	if minInterval <= 0 {
+		return ErrInvalidMonitoringInterval
+	}
+
+	rm.mutConfig.Lock()
+	rm.minMonitoringInterval = minInterval
+	rm.mutConfig.Unlock()
+
+	return nil
+}
+
+// StartMonitoring starts a go routine that saves the statistics at the provided interval until Close is called.
+// A zero or negative interval is rejected while an interval lower than the minimum one is raised to the minimum
+func (rm *ResourceMonitor) StartMonitoring(interval time.Duration) error {
+	if interval <= 0 {
+		return ErrInvalidMonitoringInterval
+	}
+
+	rm.mutConfig.RLock()
+	minInterval := rm.minMonitoringInterval
+	rm.mutConfig.RUnlock()
+
+	rm.mutFile.Lock()
+	defer rm.mutFile.Unlock()
+
+	if !rm.hasOutput() {
+		return ErrNilFileToWriteStats
+	}
+	if rm.chStopMonitoring != nil {
+		return ErrMonitoringAlreadyStarted
+	}
+
+	if interval < minInterval {
+		interval = minInterval
+	}
+
+	rm.chStopMonitoring = make(chan struct{})
+	rm.chMonitoringDone = make(chan struct{})
+	go rm.monitor(interval, rm.chStopMonitoring, rm.chMonitoringDone)
+
+	return nil
+}
+
+func (rm *ResourceMonitor) monitor(interval time.Duration, chStop chan struct{}, chDone chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer func() {
+		ticker.Stop()
+		close(chDone)
+	}()
+
+	for {
+		select {
+		case <-chStop:
+			return
+		case <-ticker.C:
+			err := rm.SaveStatistics()
+			if err != nil && rm.closed() {
+				return
+			}
+			log.LogIfError(err)
+		}
+	}
+}
+
+func (rm *ResourceMonitor) closed() bool {
+	rm.mutFile.RLock()
+	defer rm.mutFile.RUnlock()
+
+	return rm.isClosed
+}
+
 // GenerateStatistics creates a new statistic string
 func (rm *ResourceMonitor) GenerateStatistics() string {
+	stats, _ := rm.collectStatistics()
+	return stats
+}
+
+// collectStatistics returns the statistics both as the line written in the file and as the metrics pushed to sinks
+func (rm *ResourceMonitor) collectStatistics() (string, map[string]interface{}) {
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
 
 	fileDescriptors := int32(0)
 	numOpenFiles := 0
 	numConns := 0
+	connsByStatus := ""
+	metrics := make(map[string]interface{})
 	proc, err := machine.GetCurrentProcess()
 	if err == nil {
 		fileDescriptors, _ = proc.NumFDs()
@@ -48,41 +266,256 @@ func (rm *ResourceMonitor) GenerateStatistics() string {
 		conns, err := proc.Connections()
 		if err == nil {
 			numConns = len(conns)
+			connsByStatus = ", conns: " + formatConnectionsByStatus(conns)
+			metrics["conns_by_status"] = countConnectionsByStatus(conns)
 		}
 	}
 
-	return fmt.Sprintf("timestamp: %d, uptime: %v, num go: %d, alloc: %s, heap alloc: %s, heap idle: %s"+
+	timestamp := time.Now().Unix()
+	uptime := time.Duration(time.Now().UnixNano() - rm.startTime.UnixNano()).Round(time.Second)
+	numGoroutines := runtime.NumGoroutine()
+
+	metrics["timestamp"] = timestamp
+	metrics["uptime_sec"] = uint64(uptime.Seconds())
+	metrics["num_goroutines"] = numGoroutines
+	metrics["alloc_bytes"] = memStats.Alloc
+	metrics["heap_alloc_bytes"] = memStats.HeapAlloc
+	metrics["heap_idle_bytes"] = memStats.HeapIdle
+	metrics["heap_inuse_bytes"] = memStats.HeapInuse
+	metrics["heap_sys_bytes"] = memStats.HeapSys
+	metrics["heap_released_bytes"] = memStats.HeapReleased
+	metrics["heap_num_objs"] = memStats.HeapObjects
+	metrics["sys_mem_bytes"] = memStats.Sys
+	metrics["total_mem_bytes"] = memStats.TotalAlloc
+	metrics["num_gc"] = memStats.NumGC
+	metrics["num_fds"] = fileDescriptors
+	metrics["num_opened_files"] = numOpenFiles
+	metrics["num_conns"] = numConns
+
+	stats := fmt.Sprintf("timestamp: %d, uptime: %v, num go: %d, alloc: %s, heap alloc: %s, heap idle: %s"+
 		", heap inuse: %s, heap sys: %s, heap released: %s, heap num objs: %d, sys mem: %s, "+
-		"total mem: %s, num GC: %d, FDs: %d, num opened files: %d, num conns: %d\n",
-		time.Now().Unix(),
-		time.Duration(time.Now().UnixNano() - rm.startTime.UnixNano()).Round(time.Second),
-		runtime.NumGoroutine(),
-		core.ConvertBytes(memStats.Alloc),
-		core.ConvertBytes(memStats.HeapAlloc),
-		core.ConvertBytes(memStats.HeapIdle),
-		core.ConvertBytes(memStats.HeapInuse),
-		core.ConvertBytes(memStats.HeapSys),
-		core.ConvertBytes(memStats.HeapReleased),
+		"total mem: %s, num GC: %d, FDs: %d, num opened files: %d, num conns: %d",
+		timestamp,
+		uptime,
+		numGoroutines,
+		rm.formatBytes(memStats.Alloc),
+		rm.formatBytes(memStats.HeapAlloc),
+		rm.formatBytes(memStats.HeapIdle),
+		rm.formatBytes(memStats.HeapInuse),
+		rm.formatBytes(memStats.HeapSys),
+		rm.formatBytes(memStats.HeapReleased),
 		memStats.HeapObjects,
-		core.ConvertBytes(memStats.Sys),
-		core.ConvertBytes(memStats.TotalAlloc),
+		rm.formatBytes(memStats.Sys),
+		rm.formatBytes(memStats.TotalAlloc),
 		memStats.NumGC,
 		fileDescriptors,
 		numOpenFiles,
 		numConns,
 	)
+	stats += connsByStatus
+	stats += rm.generateLimitsStatistics(metrics)
+
+	return stats + rm.generateDiskStatistics(metrics) + "\n", metrics
+}
+
+func countConnectionsByStatus(conns []net.ConnectionStat) map[string]int {
+	connsByStatus := make(map[string]int)
+	for _, conn := range conns {
+		connsByStatus[conn.Status]++
+	}
+
+	return connsByStatus
+}
+
+// formatConnectionsByStatus returns the number of connections in each state as in "EST=3 TW=1 LISTEN=2".
+// The established, time wait and listen counters are always present, the other states only if encountered
+func formatConnectionsByStatus(conns []net.ConnectionStat) string {
+	connsByStatus := countConnectionsByStatus(conns)
+
+	mainStatuses := []struct {
+		status string
+		label  string
+	}{
+		{status: "ESTABLISHED", label: "EST"},
+		{status: "TIME_WAIT", label: "TW"},
+		{status: "LISTEN", label: "LISTEN"},
+	}
+
+	segments := make([]string, 0, len(connsByStatus)+len(mainStatuses))
+	for _, ms := range mainStatuses {
+		segments = append(segments, fmt.Sprintf("%s=%d", ms.label, connsByStatus[ms.status]))
+		delete(connsByStatus, ms.status)
+	}
+
+	otherStatuses := make([]string, 0, len(connsByStatus))
+	for status := range connsByStatus {
+		otherStatuses = append(otherStatuses, status)
+	}
+	sort.Strings(otherStatuses)
+	for _, status := range otherStatuses {
+		label := status
+		if len(label) == 0 {
+			label = "NONE"
+		}
+		segments = append(segments, fmt.Sprintf("%s=%d", label, connsByStatus[status]))
+	}
+
+	return strings.Join(segments, " ")
+}
+
+func (rm *ResourceMonitor) dumpGoroutinesIfNeeded(numGoroutines int, heapBytes uint64) {
+	rm.mutConfig.RLock()
+	maxGoroutines := rm.dumpMaxGoroutines
+	maxHeapBytes := rm.dumpMaxHeapBytes
+	coolDown := rm.dumpCoolDown
+	dumpFilePrefix := rm.dumpFilePrefix
+	rm.mutConfig.RUnlock()
+
+	isGoroutinesThresholdExceeded := maxGoroutines > 0 && numGoroutines > maxGoroutines
+	isHeapThresholdExceeded := maxHeapBytes > 0 && heapBytes > maxHeapBytes
+	if !isGoroutinesThresholdExceeded && !isHeapThresholdExceeded {
+		return
+	}
+
+	rm.mutDump.Lock()
+	defer rm.mutDump.Unlock()
+
+	crtTime := time.Now()
+	if !rm.lastDumpTime.IsZero() && crtTime.Sub(rm.lastDumpTime) < coolDown {
+		return
+	}
+	rm.lastDumpTime = crtTime
+
+	dumpFileName := fmt.Sprintf("%s_goroutines_%d.txt", dumpFilePrefix, crtTime.Unix())
+	err := writeGoroutinesDump(dumpFileName)
+	if err != nil {
+		log.Warn("resource monitor: can not write goroutines dump: " + err.Error())
+		return
+	}
+
+	log.Info(fmt.Sprintf("resource monitor: %d goroutines, heap alloc %s, goroutines dump written in %s",
+		numGoroutines, core.ConvertBytes(heapBytes), dumpFileName))
+}
+
+func writeGoroutinesDump(fileName string) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+
+	err = pprof.Lookup("goroutine").WriteTo(file, 1)
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// generateLimitsStatistics returns the number of threads and the open file descriptors limit of the current process
+// and adds them to the provided metrics. The fields that can not be fetched on the current platform are omitted
+func (rm *ResourceMonitor) generateLimitsStatistics(metrics map[string]interface{}) string {
+	rm.mutConfig.RLock()
+	processLimits := rm.processLimits
+	rm.mutConfig.RUnlock()
+
+	if processLimits == nil || processLimits.IsInterfaceNil() {
+		return ""
+	}
+
+	stats := ""
+	numThreads, err := processLimits.NumThreads()
+	if err == nil {
+		stats += fmt.Sprintf(", threads: %d", numThreads)
+		metrics["num_threads"] = numThreads
+	}
+
+	fdLimit, err := processLimits.FileDescriptorsLimit()
+	if err == nil {
+		stats += fmt.Sprintf(", fd limit: %d", fdLimit)
+		metrics["fd_limit"] = fdLimit
+	}
+
+	return stats
 }
 
-// SaveStatistics generates and saves statistic data on the disk
+// generateDiskStatistics returns the disk related fields and adds them to the provided metrics. The fields that
+// can not be fetched on the current platform are omitted
+func (rm *ResourceMonitor) generateDiskStatistics(metrics map[string]interface{}) string {
+	rm.mutConfig.RLock()
+	processIO := rm.processIO
+	diskUsage := rm.diskUsage
+	dataPath := rm.dataPath
+	rm.mutConfig.RUnlock()
+
+	stats := ""
+	if processIO != nil && !processIO.IsInterfaceNil() {
+		readBytes, writeBytes, err := processIO.IOCounters()
+		if err == nil {
+			stats += fmt.Sprintf(", disk read: %s, disk write: %s", rm.formatBytes(readBytes), rm.formatBytes(writeBytes))
+			metrics["disk_read_bytes"] = readBytes
+			metrics["disk_write_bytes"] = writeBytes
+		}
+	}
+
+	if len(dataPath) > 0 && diskUsage != nil && !diskUsage.IsInterfaceNil() {
+		freeBytes, err := diskUsage.FreeBytes(dataPath)
+		if err == nil {
+			stats += fmt.Sprintf(", disk free: %s", rm.formatBytes(freeBytes))
+			metrics["disk_free_bytes"] = freeBytes
+		}
+	}
+
+	return stats
+}
+
+func (rm *ResourceMonitor) formatBytes(bytes uint64) string {
+	rm.mutConfig.RLock()
+	outputRawBytes := rm.outputRawBytes
+	rm.mutConfig.RUnlock()
+
+	if !outputRawBytes {
+		return core.ConvertBytes(bytes)
+	}
+
+	return fmt.Sprintf("%s (%d)", core.ConvertBytes(bytes), bytes)
+}
+
+// SaveStatistics generates and saves statistic data on the disk, rotating the file if it grew too large
 func (rm *ResourceMonitor) SaveStatistics() error {
+	err := rm.saveStatistics()
+	if err != nil {
+		return err
+	}
+
+	return rm.rotateFileIfNeeded()
+}
+
+func (rm *ResourceMonitor) saveStatistics() error {
 	rm.mutFile.RLock()
 	defer rm.mutFile.RUnlock()
-	if rm.file == nil {
+	if !rm.hasOutput() {
 		return ErrNilFileToWriteStats
 	}
 
-	stats := rm.GenerateStatistics()
-	_, err := rm.file.WriteString(stats)
+	stats, metrics := rm.collectStatistics()
+	numGoroutines, _ := metrics["num_goroutines"].(int)
+	heapBytes, _ := metrics["heap_alloc_bytes"].(uint64)
+	rm.dumpGoroutinesIfNeeded(numGoroutines, heapBytes)
+
+	rm.notifyStatsConsumers(stats)
+	for _, sink := range rm.sinks {
+		sink.Push(metrics)
+	}
+
+	if rm.file == nil {
+		return nil
+	}
+	if !rm.hasEnoughFreeDisk() {
+		return nil
+	}
+
+	err := rm.writeStatistics(stats)
 	if err != nil {
 		return err
 	}
@@ -95,11 +528,217 @@ func (rm *ResourceMonitor) SaveStatistics() error {
 	return nil
 }
 
-// Close closes the file used for statistics
+// hasOutput returns true if the monitor was not closed and has a file or a sink to output to. The caller should
+// hold mutFile
+func (rm *ResourceMonitor) hasOutput() bool {
+	return !rm.isClosed && (rm.file != nil || len(rm.sinks) > 0)
+}
+
+func (rm *ResourceMonitor) writeStatistics(stats string) error {
+	rm.mutChecksum.Lock()
+	defer rm.mutChecksum.Unlock()
+
+	n, err := rm.file.WriteString(stats)
+	rm.checksum = crc32.Update(rm.checksum, crc32.IEEETable, []byte(stats[:n]))
+	if err != nil {
+		return err
+	}
+
+	rm.numWrittenLines++
+
+	return nil
+}
+
+func (rm *ResourceMonitor) checksumFooterLine() string {
+	rm.mutChecksum.Lock()
+	defer rm.mutChecksum.Unlock()
+
+	return fmt.Sprintf("checksum: crc32=%08x, lines: %d\n", rm.checksum, rm.numWrittenLines)
+}
+
+// writeChecksumFooter writes the checksum footer, if enabled, and resets the checksum. The caller should hold mutFile
+func (rm *ResourceMonitor) writeChecksumFooter() {
+	rm.mutConfig.RLock()
+	checksumFooter := rm.checksumFooter
+	rm.mutConfig.RUnlock()
+
+	if checksumFooter && rm.file != nil {
+		_, err := rm.file.WriteString(rm.checksumFooterLine())
+		log.LogIfError(err)
+	}
+
+	rm.mutChecksum.Lock()
+	rm.checksum = 0
+	rm.numWrittenLines = 0
+	rm.mutChecksum.Unlock()
+}
+
+func (rm *ResourceMonitor) rotateFileIfNeeded() error {
+	rm.mutConfig.RLock()
+	maxFileBytes := rm.maxFileBytes
+	maxRotatedFiles := rm.maxRotatedFiles
+	rm.mutConfig.RUnlock()
+
+	if maxFileBytes == 0 {
+		return nil
+	}
+
+	rm.mutFile.Lock()
+	defer rm.mutFile.Unlock()
+
+	if rm.file == nil {
+		return nil
+	}
+
+	fileInfo, err := rm.file.Stat()
+	if err != nil {
+		return err
+	}
+	if uint64(fileInfo.Size()) < maxFileBytes {
+		return nil
+	}
+
+	return rm.rotateFile(maxRotatedFiles)
+}
+
+// rotateFile renames the current statistics file and opens a fresh one. The caller should hold mutFile
+func (rm *ResourceMonitor) rotateFile(maxRotatedFiles int) error {
+	fileName := rm.file.Name()
+	ext := filepath.Ext(fileName)
+	base := strings.TrimSuffix(fileName, ext)
+	rotatedFileName := fmt.Sprintf("%s_rotated_%s%s", base, time.Now().Format(rotatedFileTimeFormat), ext)
+
+	rm.writeChecksumFooter()
+	err := rm.file.Close()
+	log.LogIfError(err)
+
+	errRename := os.Rename(fileName, rotatedFileName)
+	rm.file, err = os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		rm.file = nil
+		return err
+	}
+	if errRename != nil {
+		return errRename
+	}
+
+	removeOldRotatedFiles(base+"_rotated_*"+ext, maxRotatedFiles)
+
+	return nil
+}
+
+func removeOldRotatedFiles(pattern string, maxRotatedFiles int) {
+	rotatedFiles, err := filepath.Glob(pattern)
+	if err != nil {
+		log.Warn("resource monitor: can not list rotated statistics files: " + err.Error())
+		return
+	}
+	if len(rotatedFiles) <= maxRotatedFiles {
+		return
+	}
+
+	sort.Strings(rotatedFiles)
+	for _, fileName := range rotatedFiles[:len(rotatedFiles)-maxRotatedFiles] {
+		err = os.Remove(fileName)
+		if err != nil {
+			log.Warn("resource monitor: can not remove rotated statistics file: " + err.Error())
+		}
+	}
+}
+
+func (rm *ResourceMonitor) hasEnoughFreeDisk() bool {
+	rm.mutConfig.RLock()
+	diskPath := rm.diskPath
+	minFreeDiskBytes := rm.minFreeDiskBytes
+	diskUsage := rm.diskUsage
+	rm.mutConfig.RUnlock()
+
+	if len(diskPath) == 0 {
+		return true
+	}
+
+	freeBytes, err := diskUsage.FreeBytes(diskPath)
+	if err != nil {
+		log.Debug("resource monitor: can not get free disk space: " + err.Error())
+		return true
+	}
+	if freeBytes < minFreeDiskBytes {
+		log.Warn(fmt.Sprintf("resource monitor: skipped writing statistics, free disk space %s is below %s",
+			core.ConvertBytes(freeBytes), core.ConvertBytes(minFreeDiskBytes)))
+		return false
+	}
+
+	return true
+}
+
+// StatsChannel returns the channel on which every saved statistics line is published. The channel is created by
+// the first call, so no line is published unless requested, and it is closed by Close after the monitoring go
+// routine stopped. If the consumer does not keep up, the lines that do not fit in the channel's buffer are dropped
+func (rm *ResourceMonitor) StatsChannel() <-chan string {
+	rm.mutFile.Lock()
+	defer rm.mutFile.Unlock()
+
+	if rm.statsChan != nil {
+		return rm.statsChan
+	}
+
+	statsChan := make(chan string, statsChannelSize)
+	if rm.isClosed {
+		close(statsChan)
+		return statsChan
+	}
+	rm.statsChan = statsChan
+
+	return statsChan
+}
+
+// notifyStatsConsumers publishes the statistics line on the stats channel, if requested. The caller should hold
+// the mutFile
+func (rm *ResourceMonitor) notifyStatsConsumers(stats string) {
+	if rm.statsChan == nil {
+		return
+	}
+
+	select {
+	case rm.statsChan <- stats:
+	default:
+	}
+}
+
+// Close stops the monitoring and closes the file used for statistics, writing the checksum footer first if it
+// was enabled. The monitoring go routine is stopped before the file and the stats channel are closed. The
+// statistics are no longer saved after Close, not even to sinks. Calling Close more than once has no effect
 func (rm *ResourceMonitor) Close() error {
+	rm.mutFile.Lock()
+	if rm.isClosed {
+		rm.mutFile.Unlock()
+		return nil
+	}
+	rm.isClosed = true
+	chStop := rm.chStopMonitoring
+	chDone := rm.chMonitoringDone
+	rm.chStopMonitoring = nil
+	rm.chMonitoringDone = nil
+	rm.mutFile.Unlock()
+
+	if chStop != nil {
+		close(chStop)
+		<-chDone
+	}
+
 	rm.mutFile.Lock()
 	defer rm.mutFile.Unlock()
 
+	if rm.statsChan != nil {
+		close(rm.statsChan)
+	}
+
+	if rm.file == nil {
+		return nil
+	}
+
+	rm.writeChecksumFooter()
+
 	err := rm.file.Close()
 	rm.file = nil
 	return err
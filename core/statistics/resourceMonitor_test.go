@@ -1,17 +1,28 @@
 package statistics_test
 
 import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/ElrondNetwork/elrond-go/core/mock"
 	stats "github.com/ElrondNetwork/elrond-go/core/statistics"
+	"github.com/shirou/gopsutil/net"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestResourceMonitor_NewResourceMonitorNilFileShouldErr(t *testing.T) {
 	t.Parallel()
 
-	resourceMonitor, err := stats.NewResourceMonitor(nil)
+	resourceMonitor, err := stats.NewResourceMonitor(nil, "")
 
 	assert.Nil(t, resourceMonitor)
 	assert.Equal(t, stats.ErrNilFileToWriteStats, err)
@@ -20,7 +31,7 @@ func TestResourceMonitor_NewResourceMonitorNilFileShouldErr(t *testing.T) {
 func TestResourceMonitor_NewResourceMonitorShouldPass(t *testing.T) {
 	t.Parallel()
 
-	resourceMonitor, err := stats.NewResourceMonitor(&os.File{})
+	resourceMonitor, err := stats.NewResourceMonitor(&os.File{}, "")
 
 	assert.NotNil(t, resourceMonitor)
 	assert.Nil(t, err)
@@ -29,7 +40,7 @@ func TestResourceMonitor_NewResourceMonitorShouldPass(t *testing.T) {
 func TestResourceMonitor_GenerateStatisticsShouldPass(t *testing.T) {
 	t.Parallel()
 
-	resourceMonitor, err := stats.NewResourceMonitor(&os.File{})
+	resourceMonitor, err := stats.NewResourceMonitor(&os.File{}, "")
 	assert.Nil(t, err)
 
 	statistics := resourceMonitor.GenerateStatistics()
@@ -44,7 +55,7 @@ func TestResourceMonitor_SaveStatisticsShouldPass(t *testing.T) {
 	file, err := os.Create("test1")
 	assert.Nil(t, err)
 
-	resourceMonitor, _ := stats.NewResourceMonitor(file)
+	resourceMonitor, _ := stats.NewResourceMonitor(file, "")
 
 	err = resourceMonitor.SaveStatistics()
 	if _, errF := os.Stat("test1"); errF == nil {
@@ -61,7 +72,7 @@ func TestResourceMonitor_SaveStatisticsCloseFileBeforeSaveShouldErr(t *testing.T
 	file, err := os.Create("test2")
 	assert.Nil(t, err)
 
-	resourceMonitor, _ := stats.NewResourceMonitor(file)
+	resourceMonitor, _ := stats.NewResourceMonitor(file, "")
 
 	err = resourceMonitor.Close()
 	assert.Nil(t, err)
@@ -80,7 +91,7 @@ func TestResourceMonitor_CloseShouldPass(t *testing.T) {
 	file, err := os.Create("test3")
 	assert.Nil(t, err)
 
-	resourceMonitor, err := stats.NewResourceMonitor(file)
+	resourceMonitor, err := stats.NewResourceMonitor(file, "")
 	assert.Nil(t, err)
 
 	err = resourceMonitor.Close()
@@ -90,3 +101,750 @@ func TestResourceMonitor_CloseShouldPass(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestResourceMonitor_SaveStatisticsShouldPublishOnStatsChannel(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.Create("test4")
+	assert.Nil(t, err)
+
+	resourceMonitor, _ := stats.NewResourceMonitor(file, "")
+	statsChannel := resourceMonitor.StatsChannel()
+
+	err = resourceMonitor.SaveStatistics()
+	assert.Nil(t, err)
+
+	select {
+	case line := <-statsChannel:
+		assert.Contains(t, line, "timestamp:")
+	default:
+		assert.Fail(t, "statistics line should have been published")
+	}
+
+	_ = resourceMonitor.Close()
+	if _, errF := os.Stat("test4"); errF == nil {
+		_ = os.Remove("test4")
+	}
+}
+
+func TestResourceMonitor_SaveStatisticsSlowConsumerShouldNotBlock(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.Create("test5")
+	assert.Nil(t, err)
+
+	resourceMonitor, _ := stats.NewResourceMonitor(file, "")
+
+	numSaves := 2 * cap(resourceMonitor.StatsChannel())
+	chDone := make(chan struct{})
+	go func() {
+		for i := 0; i < numSaves; i++ {
+			_ = resourceMonitor.SaveStatistics()
+		}
+		close(chDone)
+	}()
+
+	select {
+	case <-chDone:
+	case <-time.After(time.Second * 10):
+		assert.Fail(t, "saving statistics should not block on a full channel")
+	}
+
+	assert.Equal(t, cap(resourceMonitor.StatsChannel()), len(resourceMonitor.StatsChannel()))
+
+	_ = resourceMonitor.Close()
+	if _, errF := os.Stat("test5"); errF == nil {
+		_ = os.Remove("test5")
+	}
+}
+
+func TestResourceMonitor_CloseShouldCloseStatsChannel(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, _ := stats.NewResourceMonitor(nil, "", &mock.StatisticsSinkStub{
+		PushCalled: func(metrics map[string]interface{}) {},
+	})
+	statsChannel := resourceMonitor.StatsChannel()
+	_ = resourceMonitor.StartMonitoring(time.Millisecond)
+	time.Sleep(time.Millisecond * 100)
+
+	_ = resourceMonitor.Close()
+
+	chDone := make(chan struct{})
+	go func() {
+		for range statsChannel {
+		}
+		close(chDone)
+	}()
+
+	select {
+	case <-chDone:
+	case <-time.After(time.Second * 10):
+		assert.Fail(t, "ranging over the stats channel should end after Close")
+	}
+}
+
+func TestResourceMonitor_StatsChannelRequestedAfterCloseShouldBeClosed(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, _ := stats.NewResourceMonitor(nil, "", &mock.StatisticsSinkStub{})
+	_ = resourceMonitor.Close()
+
+	_, ok := <-resourceMonitor.StatsChannel()
+
+	assert.False(t, ok)
+}
+
+func TestResourceMonitor_SetMinMonitoringIntervalZeroShouldErr(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, _ := stats.NewResourceMonitor(&os.File{}, "")
+
+	err := resourceMonitor.SetMinMonitoringInterval(0)
+
+	assert.Equal(t, stats.ErrInvalidMonitoringInterval, err)
+}
+
+func TestResourceMonitor_StartMonitoringZeroIntervalShouldErr(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, _ := stats.NewResourceMonitor(&os.File{}, "")
+
+	err := resourceMonitor.StartMonitoring(0)
+
+	assert.Equal(t, stats.ErrInvalidMonitoringInterval, err)
+}
+
+func TestResourceMonitor_StartMonitoringSubMinimumIntervalShouldClamp(t *testing.T) {
+	t.Parallel()
+
+	mutPushes := sync.Mutex{}
+	numPushes := 0
+	resourceMonitor, _ := stats.NewResourceMonitor(nil, "", &mock.StatisticsSinkStub{
+		PushCalled: func(metrics map[string]interface{}) {
+			mutPushes.Lock()
+			numPushes++
+			mutPushes.Unlock()
+		},
+	})
+	_ = resourceMonitor.SetMinMonitoringInterval(time.Millisecond * 100)
+
+	err := resourceMonitor.StartMonitoring(time.Nanosecond)
+	assert.Nil(t, err)
+
+	err = resourceMonitor.StartMonitoring(time.Second)
+	assert.Equal(t, stats.ErrMonitoringAlreadyStarted, err)
+
+	time.Sleep(time.Millisecond * 350)
+	_ = resourceMonitor.Close()
+
+	mutPushes.Lock()
+	defer mutPushes.Unlock()
+	assert.True(t, numPushes > 0)
+	assert.True(t, numPushes <= 4)
+}
+
+func TestResourceMonitor_StartMonitoringIntervalAboveMinimumShouldNotClamp(t *testing.T) {
+	t.Parallel()
+
+	mutPushes := sync.Mutex{}
+	numPushes := 0
+	resourceMonitor, _ := stats.NewResourceMonitor(nil, "", &mock.StatisticsSinkStub{
+		PushCalled: func(metrics map[string]interface{}) {
+			mutPushes.Lock()
+			numPushes++
+			mutPushes.Unlock()
+		},
+	})
+	_ = resourceMonitor.SetMinMonitoringInterval(time.Millisecond * 10)
+
+	err := resourceMonitor.StartMonitoring(time.Millisecond * 100)
+	assert.Nil(t, err)
+
+	time.Sleep(time.Millisecond * 350)
+	_ = resourceMonitor.Close()
+
+	mutPushes.Lock()
+	defer mutPushes.Unlock()
+	assert.True(t, numPushes > 0)
+	assert.True(t, numPushes <= 4)
+}
+
+func TestResourceMonitor_GenerateStatisticsDefaultShouldNotOutputRawBytes(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, _ := stats.NewResourceMonitor(&os.File{}, "")
+
+	statistics := resourceMonitor.GenerateStatistics()
+
+	rawBytesRegexp := regexp.MustCompile(`alloc: [0-9.]+ [A-Z]*B \([0-9]+\)`)
+	assert.False(t, rawBytesRegexp.MatchString(statistics))
+}
+
+func TestResourceMonitor_GenerateStatisticsWithRawBytesShouldOutputBoth(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, _ := stats.NewResourceMonitor(&os.File{}, "")
+	resourceMonitor.SetOutputRawBytes(true)
+
+	statistics := resourceMonitor.GenerateStatistics()
+
+	for _, field := range []string{"alloc", "heap alloc", "heap idle", "heap inuse", "heap sys", "sys mem", "total mem"} {
+		fieldRegexp := regexp.MustCompile(field + `: [0-9.]+ [A-Z]*B \([0-9]+\)`)
+		assert.True(t, fieldRegexp.MatchString(statistics), field)
+	}
+}
+
+func TestResourceMonitor_SetMinFreeDiskCheckInvalidArgumentsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, _ := stats.NewResourceMonitor(&os.File{}, "")
+
+	err := resourceMonitor.SetMinFreeDiskCheck("", 1, &mock.DiskUsageHandlerStub{})
+	assert.Equal(t, stats.ErrEmptyDiskPath, err)
+
+	err = resourceMonitor.SetMinFreeDiskCheck(".", 1, nil)
+	assert.Equal(t, stats.ErrNilDiskUsageHandler, err)
+}
+
+func TestResourceMonitor_SaveStatisticsBelowMinFreeDiskShouldSkipWrite(t *testing.T) {
+	t.Parallel()
+
+	fileName := "test8"
+	file, err := os.Create(fileName)
+	assert.Nil(t, err)
+
+	resourceMonitor, _ := stats.NewResourceMonitor(file, "")
+	queriedPath := ""
+	err = resourceMonitor.SetMinFreeDiskCheck(".", 1000, &mock.DiskUsageHandlerStub{
+		FreeBytesCalled: func(path string) (uint64, error) {
+			queriedPath = path
+			return 999, nil
+		},
+	})
+	assert.Nil(t, err)
+
+	err = resourceMonitor.SaveStatistics()
+	assert.Nil(t, err)
+	assert.Equal(t, ".", queriedPath)
+
+	_ = resourceMonitor.Close()
+	content, _ := ioutil.ReadFile(fileName)
+	_ = os.Remove(fileName)
+
+	assert.Equal(t, 0, len(content))
+}
+
+func TestResourceMonitor_SaveStatisticsAboveMinFreeDiskShouldWrite(t *testing.T) {
+	t.Parallel()
+
+	fileName := "test9"
+	file, err := os.Create(fileName)
+	assert.Nil(t, err)
+
+	resourceMonitor, _ := stats.NewResourceMonitor(file, "")
+	_ = resourceMonitor.SetMinFreeDiskCheck(".", 1000, &mock.DiskUsageHandlerStub{
+		FreeBytesCalled: func(path string) (uint64, error) {
+			return 1000, nil
+		},
+	})
+
+	err = resourceMonitor.SaveStatistics()
+	assert.Nil(t, err)
+
+	_ = resourceMonitor.Close()
+	content, _ := ioutil.ReadFile(fileName)
+	_ = os.Remove(fileName)
+
+	assert.True(t, len(content) > 0)
+}
+
+func TestResourceMonitor_CloseWithChecksumFooterShouldAppendMatchingFooter(t *testing.T) {
+	t.Parallel()
+
+	fileName := "test10"
+	file, err := os.Create(fileName)
+	assert.Nil(t, err)
+
+	resourceMonitor, _ := stats.NewResourceMonitor(file, "")
+	resourceMonitor.SetChecksumFooter(true)
+
+	numWrites := 3
+	for i := 0; i < numWrites; i++ {
+		err = resourceMonitor.SaveStatistics()
+		assert.Nil(t, err)
+	}
+
+	err = resourceMonitor.Close()
+	assert.Nil(t, err)
+	content, _ := ioutil.ReadFile(fileName)
+	_ = os.Remove(fileName)
+
+	lines := strings.SplitAfter(string(content), "\n")
+	// the last element is the empty string following the footer's new line
+	assert.Equal(t, numWrites+2, len(lines))
+
+	footer := lines[numWrites]
+	statsContent := strings.Join(lines[:numWrites], "")
+	expectedFooter := fmt.Sprintf("checksum: crc32=%08x, lines: %d\n", crc32.ChecksumIEEE([]byte(statsContent)), numWrites)
+	assert.Equal(t, expectedFooter, footer)
+}
+
+func TestResourceMonitor_CloseWithoutChecksumFooterShouldNotAppendFooter(t *testing.T) {
+	t.Parallel()
+
+	fileName := "test11"
+	file, err := os.Create(fileName)
+	assert.Nil(t, err)
+
+	resourceMonitor, _ := stats.NewResourceMonitor(file, "")
+	_ = resourceMonitor.SaveStatistics()
+
+	_ = resourceMonitor.Close()
+	content, _ := ioutil.ReadFile(fileName)
+	_ = os.Remove(fileName)
+
+	assert.False(t, strings.Contains(string(content), "checksum"))
+}
+
+func TestResourceMonitor_GenerateStatisticsShouldOutputDiskStatistics(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, _ := stats.NewResourceMonitor(&os.File{}, "data")
+	resourceMonitor.SetProcessIOHandler(&mock.ProcessIOHandlerStub{
+		IOCountersCalled: func() (uint64, uint64, error) {
+			return 2048, 3 * 1024 * 1024, nil
+		},
+	})
+	queriedPath := ""
+	resourceMonitor.SetDiskUsageHandler(&mock.DiskUsageHandlerStub{
+		FreeBytesCalled: func(path string) (uint64, error) {
+			queriedPath = path
+			return 5 * 1024 * 1024 * 1024, nil
+		},
+	})
+
+	statistics := resourceMonitor.GenerateStatistics()
+
+	assert.Equal(t, "data", queriedPath)
+	assert.True(t, strings.Contains(statistics, "disk read: 2.00 KB, disk write: 3.00 MB, disk free: 5.00 GB\n"))
+}
+
+func TestResourceMonitor_GenerateStatisticsUnavailableDiskStatisticsShouldOmitThem(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, _ := stats.NewResourceMonitor(&os.File{}, "data")
+	resourceMonitor.SetProcessIOHandler(&mock.ProcessIOHandlerStub{
+		IOCountersCalled: func() (uint64, uint64, error) {
+			return 0, 0, errors.New("not implemented")
+		},
+	})
+	resourceMonitor.SetDiskUsageHandler(&mock.DiskUsageHandlerStub{
+		FreeBytesCalled: func(path string) (uint64, error) {
+			return 0, errors.New("not found")
+		},
+	})
+	resourceMonitor.SetProcessLimitsHandler(&mock.ProcessLimitsHandlerStub{
+		NumThreadsCalled: func() (int32, error) {
+			return 0, errors.New("not implemented")
+		},
+		FileDescriptorsLimitCalled: func() (uint64, error) {
+			return 0, errors.New("not implemented")
+		},
+	})
+
+	statistics := resourceMonitor.GenerateStatistics()
+
+	assert.False(t, strings.Contains(statistics, "disk"))
+	assert.True(t, regexp.MustCompile(`num conns: \d+(, conns: [^,]*)?\n$`).MatchString(statistics))
+}
+
+func TestResourceMonitor_GenerateStatisticsShouldOutputThreadsAndFileDescriptorsLimit(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, _ := stats.NewResourceMonitor(&os.File{}, "")
+	resourceMonitor.SetProcessLimitsHandler(&mock.ProcessLimitsHandlerStub{
+		NumThreadsCalled: func() (int32, error) {
+			return 17, nil
+		},
+		FileDescriptorsLimitCalled: func() (uint64, error) {
+			return 65535, nil
+		},
+	})
+
+	statistics := resourceMonitor.GenerateStatistics()
+
+	assert.True(t, strings.Contains(statistics, ", threads: 17, fd limit: 65535"))
+}
+
+func TestResourceMonitor_GenerateStatisticsUnavailableFileDescriptorsLimitShouldOmitIt(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, _ := stats.NewResourceMonitor(&os.File{}, "")
+	resourceMonitor.SetProcessLimitsHandler(&mock.ProcessLimitsHandlerStub{
+		NumThreadsCalled: func() (int32, error) {
+			return 17, nil
+		},
+		FileDescriptorsLimitCalled: func() (uint64, error) {
+			return 0, errors.New("not implemented")
+		},
+	})
+
+	statistics := resourceMonitor.GenerateStatistics()
+
+	assert.True(t, strings.Contains(statistics, ", threads: 17"))
+	assert.False(t, strings.Contains(statistics, "fd limit"))
+}
+
+func TestResourceMonitor_GenerateStatisticsEmptyDataPathShouldNotOutputFreeDisk(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, _ := stats.NewResourceMonitor(&os.File{}, "")
+	resourceMonitor.SetDiskUsageHandler(&mock.DiskUsageHandlerStub{
+		FreeBytesCalled: func(path string) (uint64, error) {
+			assert.Fail(t, "should have not been called")
+			return 0, nil
+		},
+	})
+
+	statistics := resourceMonitor.GenerateStatistics()
+
+	assert.False(t, strings.Contains(statistics, "disk free"))
+}
+
+func TestResourceMonitor_SetGoroutinesDumpThresholdsInvalidValuesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, _ := stats.NewResourceMonitor(&os.File{}, "")
+
+	err := resourceMonitor.SetGoroutinesDumpThresholds(-1, 0, time.Second)
+	assert.Equal(t, stats.ErrInvalidDumpThresholds, err)
+
+	err = resourceMonitor.SetGoroutinesDumpThresholds(1, 0, -time.Second)
+	assert.Equal(t, stats.ErrInvalidDumpThresholds, err)
+}
+
+func TestResourceMonitor_SaveStatisticsAboveThresholdShouldDumpOncePerCoolDown(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "resourceMonitor")
+	assert.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	file, err := os.Create(filepath.Join(dir, "stats.txt"))
+	assert.Nil(t, err)
+
+	resourceMonitor, _ := stats.NewResourceMonitor(file, "")
+	err = resourceMonitor.SetGoroutinesDumpThresholds(1, 0, time.Hour)
+	assert.Nil(t, err)
+
+	for i := 0; i < 3; i++ {
+		_ = resourceMonitor.SaveStatistics()
+	}
+	_ = resourceMonitor.Close()
+
+	dumps, err := filepath.Glob(filepath.Join(dir, "stats_goroutines_*.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(dumps))
+
+	content, _ := ioutil.ReadFile(dumps[0])
+	assert.True(t, strings.Contains(string(content), "goroutine profile"))
+}
+
+func TestResourceMonitor_SaveStatisticsBelowThresholdShouldNotDump(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "resourceMonitor")
+	assert.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	file, err := os.Create(filepath.Join(dir, "stats.txt"))
+	assert.Nil(t, err)
+
+	resourceMonitor, _ := stats.NewResourceMonitor(file, "")
+	_ = resourceMonitor.SetGoroutinesDumpThresholds(1000000, 0, 0)
+
+	_ = resourceMonitor.SaveStatistics()
+	_ = resourceMonitor.Close()
+
+	dumps, _ := filepath.Glob(filepath.Join(dir, "stats_goroutines_*.txt"))
+	assert.Equal(t, 0, len(dumps))
+}
+
+func TestResourceMonitor_GenerateStatisticsAboveThresholdShouldNotDump(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "resourceMonitor")
+	assert.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	file, err := os.Create(filepath.Join(dir, "stats.txt"))
+	assert.Nil(t, err)
+
+	resourceMonitor, _ := stats.NewResourceMonitor(file, "")
+	_ = resourceMonitor.SetGoroutinesDumpThresholds(1, 0, 0)
+
+	_ = resourceMonitor.GenerateStatistics()
+	_ = resourceMonitor.Close()
+
+	dumps, _ := filepath.Glob(filepath.Join(dir, "stats_goroutines_*.txt"))
+	assert.Equal(t, 0, len(dumps))
+}
+
+func TestResourceMonitor_SetFileRotationInvalidValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, _ := stats.NewResourceMonitor(&os.File{}, "")
+
+	err := resourceMonitor.SetFileRotation(1, -1)
+
+	assert.Equal(t, stats.ErrInvalidMaxRotatedFiles, err)
+}
+
+func TestResourceMonitor_SaveStatisticsPastLimitShouldRotateAndKeepMaxRotatedFiles(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "resourceMonitor")
+	assert.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	fileName := filepath.Join(dir, "stats.txt")
+	file, err := os.Create(fileName)
+	assert.Nil(t, err)
+
+	resourceMonitor, _ := stats.NewResourceMonitor(file, "")
+	resourceMonitor.SetChecksumFooter(true)
+	maxRotatedFiles := 2
+	err = resourceMonitor.SetFileRotation(1, maxRotatedFiles)
+	assert.Nil(t, err)
+
+	for i := 0; i < 5; i++ {
+		err = resourceMonitor.SaveStatistics()
+		assert.Nil(t, err)
+	}
+
+	rotatedFiles, _ := filepath.Glob(filepath.Join(dir, "stats_rotated_*.txt"))
+	assert.Equal(t, maxRotatedFiles, len(rotatedFiles))
+	for _, rotatedFile := range rotatedFiles {
+		content, _ := ioutil.ReadFile(rotatedFile)
+		assert.True(t, strings.HasSuffix(string(content), ", lines: 1\n"))
+	}
+
+	content, _ := ioutil.ReadFile(fileName)
+	assert.Equal(t, 0, len(content))
+
+	err = resourceMonitor.SaveStatistics()
+	assert.Nil(t, err)
+	_ = resourceMonitor.Close()
+
+	rotatedFiles, _ = filepath.Glob(filepath.Join(dir, "stats_rotated_*.txt"))
+	assert.Equal(t, maxRotatedFiles, len(rotatedFiles))
+}
+
+func TestResourceMonitor_SaveStatisticsBelowLimitShouldNotRotate(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "resourceMonitor")
+	assert.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	fileName := filepath.Join(dir, "stats.txt")
+	file, err := os.Create(fileName)
+	assert.Nil(t, err)
+
+	resourceMonitor, _ := stats.NewResourceMonitor(file, "")
+	_ = resourceMonitor.SetFileRotation(1024*1024, 2)
+
+	for i := 0; i < 3; i++ {
+		_ = resourceMonitor.SaveStatistics()
+	}
+	_ = resourceMonitor.Close()
+
+	rotatedFiles, _ := filepath.Glob(filepath.Join(dir, "stats_rotated_*.txt"))
+	assert.Equal(t, 0, len(rotatedFiles))
+
+	content, _ := ioutil.ReadFile(fileName)
+	assert.Equal(t, 3, strings.Count(string(content), "\n"))
+}
+
+func TestResourceMonitor_FormatConnectionsByStatusShouldBucketByStatus(t *testing.T) {
+	t.Parallel()
+
+	conns := []net.ConnectionStat{
+		{Status: "ESTABLISHED"},
+		{Status: "TIME_WAIT"},
+		{Status: "ESTABLISHED"},
+		{Status: "CLOSE_WAIT"},
+		{Status: "LISTEN"},
+		{Status: "ESTABLISHED"},
+		{Status: ""},
+		{Status: "CLOSE_WAIT"},
+	}
+
+	formatted := stats.FormatConnectionsByStatus(conns)
+
+	assert.Equal(t, "EST=3 TW=1 LISTEN=1 NONE=1 CLOSE_WAIT=2", formatted)
+}
+
+func TestResourceMonitor_FormatConnectionsByStatusNoConnectionsShouldOutputMainStatuses(t *testing.T) {
+	t.Parallel()
+
+	formatted := stats.FormatConnectionsByStatus(nil)
+
+	assert.Equal(t, "EST=0 TW=0 LISTEN=0", formatted)
+}
+
+func TestResourceMonitor_GenerateStatisticsShouldKeepConnectionsTotal(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, _ := stats.NewResourceMonitor(&os.File{}, "")
+
+	statistics := resourceMonitor.GenerateStatistics()
+
+	assert.True(t, regexp.MustCompile(`num conns: \d+, conns: EST=\d+ TW=\d+ LISTEN=\d+`).MatchString(statistics))
+}
+
+func TestResourceMonitor_NewResourceMonitorNilSinkShouldErr(t *testing.T) {
+	t.Parallel()
+
+	resourceMonitor, err := stats.NewResourceMonitor(&os.File{}, "", nil)
+
+	assert.Nil(t, resourceMonitor)
+	assert.Equal(t, stats.ErrNilStatisticsSink, err)
+}
+
+func TestResourceMonitor_SaveStatisticsWithoutFileShouldPushToSink(t *testing.T) {
+	t.Parallel()
+
+	pushedMetrics := make([]map[string]interface{}, 0)
+	sink := &mock.StatisticsSinkStub{
+		PushCalled: func(metrics map[string]interface{}) {
+			pushedMetrics = append(pushedMetrics, metrics)
+		},
+	}
+	resourceMonitor, err := stats.NewResourceMonitor(nil, "", sink)
+	assert.Nil(t, err)
+
+	numTicks := 3
+	for i := 0; i < numTicks; i++ {
+		err = resourceMonitor.SaveStatistics()
+		assert.Nil(t, err)
+	}
+
+	assert.Equal(t, numTicks, len(pushedMetrics))
+	for _, metrics := range pushedMetrics {
+		assert.True(t, metrics["num_goroutines"].(int) > 0)
+		assert.True(t, metrics["heap_alloc_bytes"].(uint64) > 0)
+		_, ok := metrics["timestamp"]
+		assert.True(t, ok)
+	}
+
+	_ = resourceMonitor.Close()
+	err = resourceMonitor.SaveStatistics()
+	assert.Equal(t, stats.ErrNilFileToWriteStats, err)
+	assert.Equal(t, numTicks, len(pushedMetrics))
+}
+
+func TestResourceMonitor_SaveStatisticsShouldFanOutToFileAndAllSinks(t *testing.T) {
+	t.Parallel()
+
+	fileName := "test12"
+	file, err := os.Create(fileName)
+	assert.Nil(t, err)
+
+	numPushes1 := 0
+	numPushes2 := 0
+	resourceMonitor, _ := stats.NewResourceMonitor(
+		file,
+		"",
+		&mock.StatisticsSinkStub{
+			PushCalled: func(metrics map[string]interface{}) {
+				numPushes1++
+			},
+		},
+		&mock.StatisticsSinkStub{
+			PushCalled: func(metrics map[string]interface{}) {
+				numPushes2++
+			},
+		},
+	)
+
+	err = resourceMonitor.SaveStatistics()
+	assert.Nil(t, err)
+
+	_ = resourceMonitor.Close()
+	content, _ := ioutil.ReadFile(fileName)
+	_ = os.Remove(fileName)
+
+	assert.Equal(t, 1, strings.Count(string(content), "\n"))
+	assert.Equal(t, 1, numPushes1)
+	assert.Equal(t, 1, numPushes2)
+}
+
+func TestResourceMonitor_StartMonitoringShouldWriteUntilClosed(t *testing.T) {
+	t.Parallel()
+
+	fileName := "test13"
+	file, err := os.Create(fileName)
+	assert.Nil(t, err)
+
+	resourceMonitor, _ := stats.NewResourceMonitor(file, "")
+	interval := time.Millisecond * 10
+	_ = resourceMonitor.SetMinMonitoringInterval(interval)
+
+	err = resourceMonitor.StartMonitoring(interval)
+	assert.Nil(t, err)
+
+	time.Sleep(interval * 20)
+	err = resourceMonitor.Close()
+	assert.Nil(t, err)
+
+	content, _ := ioutil.ReadFile(fileName)
+	numWrites := strings.Count(string(content), "\n")
+	assert.True(t, numWrites > 1)
+
+	time.Sleep(interval * 5)
+	content, _ = ioutil.ReadFile(fileName)
+	_ = os.Remove(fileName)
+	assert.Equal(t, numWrites, strings.Count(string(content), "\n"))
+}
+
+func TestResourceMonitor_CloseConcurrentlyShouldCloseOnce(t *testing.T) {
+	t.Parallel()
+
+	fileName := "test14"
+	file, err := os.Create(fileName)
+	assert.Nil(t, err)
+
+	resourceMonitor, _ := stats.NewResourceMonitor(file, "")
+	resourceMonitor.SetChecksumFooter(true)
+	interval := time.Millisecond
+	_ = resourceMonitor.SetMinMonitoringInterval(interval)
+	_ = resourceMonitor.StartMonitoring(interval)
+	time.Sleep(interval * 10)
+
+	numCalls := 10
+	wg := sync.WaitGroup{}
+	wg.Add(numCalls)
+	for i := 0; i < numCalls; i++ {
+		go func() {
+			assert.Nil(t, resourceMonitor.Close())
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+
+	content, _ := ioutil.ReadFile(fileName)
+	_ = os.Remove(fileName)
+	assert.Equal(t, 1, strings.Count(string(content), "checksum: "))
+}
@@ -0,0 +1,90 @@
+package dataPool
+
+import (
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
+// CacheSize holds the number of items currently stored in a pool and the maximum number of items it can hold.
+// For the sharded pools, the number of items is summed over all the shard stores while the capacity is the one
+// of each shard store
+type CacheSize struct {
+	NumItems int
+	Capacity int
+}
+
+// ShardPoolsSizes holds a snapshot of the sizes of the shard data pools
+type ShardPoolsSizes struct {
+	Transactions         CacheSize
+	UnsignedTransactions CacheSize
+	RewardTransactions   CacheSize
+	Headers              CacheSize
+	MiniBlocks           CacheSize
+	PeerChangesBlocks    CacheSize
+	MetaBlocks           CacheSize
+	ValidatorInfo        CacheSize
+}
+
+// MetaPoolsSizes holds a snapshot of the sizes of the metachain data pools
+type MetaPoolsSizes struct {
+	MetaBlocks           CacheSize
+	MiniBlocks           CacheSize
+	ShardHeaders         CacheSize
+	Transactions         CacheSize
+	UnsignedTransactions CacheSize
+}
+
+// GetShardPoolsSizes returns the current number of items and the capacity of each of the provided shard data pools
+func GetShardPoolsSizes(pools dataRetriever.PoolsHolder) ShardPoolsSizes {
+	if pools == nil || pools.IsInterfaceNil() {
+		return ShardPoolsSizes{}
+	}
+
+	return ShardPoolsSizes{
+		Transactions:         shardedDataSize(pools.Transactions()),
+		UnsignedTransactions: shardedDataSize(pools.UnsignedTransactions()),
+		RewardTransactions:   shardedDataSize(pools.RewardTransactions()),
+		Headers:              cacherSize(pools.Headers()),
+		MiniBlocks:           cacherSize(pools.MiniBlocks()),
+		PeerChangesBlocks:    cacherSize(pools.PeerChangesBlocks()),
+		MetaBlocks:           cacherSize(pools.MetaBlocks()),
+		ValidatorInfo:        cacherSize(pools.ValidatorInfo()),
+	}
+}
+
+// GetMetaPoolsSizes returns the current number of items and the capacity of each of the provided metachain data pools
+func GetMetaPoolsSizes(pools dataRetriever.MetaPoolsHolder) MetaPoolsSizes {
+	if pools == nil || pools.IsInterfaceNil() {
+		return MetaPoolsSizes{}
+	}
+
+	return MetaPoolsSizes{
+		MetaBlocks:           cacherSize(pools.MetaBlocks()),
+		MiniBlocks:           cacherSize(pools.MiniBlocks()),
+		ShardHeaders:         cacherSize(pools.ShardHeaders()),
+		Transactions:         shardedDataSize(pools.Transactions()),
+		UnsignedTransactions: shardedDataSize(pools.UnsignedTransactions()),
+	}
+}
+
+func cacherSize(cacher storage.Cacher) CacheSize {
+	if cacher == nil || cacher.IsInterfaceNil() {
+		return CacheSize{}
+	}
+
+	return CacheSize{
+		NumItems: cacher.Len(),
+		Capacity: cacher.MaxSize(),
+	}
+}
+
+func shardedDataSize(shardedData dataRetriever.ShardedDataCacherNotifier) CacheSize {
+	if shardedData == nil || shardedData.IsInterfaceNil() {
+		return CacheSize{}
+	}
+
+	return CacheSize{
+		NumItems: shardedData.Len(),
+		Capacity: shardedData.MaxSize(),
+	}
+}
@@ -0,0 +1,105 @@
+package dataPool_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever/dataPool"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever/mock"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever/shardedData"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/stretchr/testify/assert"
+)
+
+func createLruCache(t *testing.T, size uint32) storage.Cacher {
+	cacher, err := storageUnit.NewCache(storageUnit.LRUCache, size, 1)
+	assert.Nil(t, err)
+
+	return cacher
+}
+
+func createShardedData(t *testing.T, size uint32) dataRetriever.ShardedDataCacherNotifier {
+	sd, err := shardedData.NewShardedData(storageUnit.CacheConfig{Size: size, Type: storageUnit.LRUCache})
+	assert.Nil(t, err)
+
+	return sd
+}
+
+func TestGetShardPoolsSizes_NilPoolsShouldReturnEmpty(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, dataPool.ShardPoolsSizes{}, dataPool.GetShardPoolsSizes(nil))
+}
+
+func TestGetShardPoolsSizes_ShouldReportItemsAndCapacities(t *testing.T) {
+	t.Parallel()
+
+	txs := createShardedData(t, 10)
+	txs.AddData([]byte("tx1"), "tx1", "0")
+	txs.AddData([]byte("tx2"), "tx2", "0")
+	txs.AddData([]byte("tx3"), "tx3", "0_1")
+	headers := createLruCache(t, 5)
+	_ = headers.Put([]byte("hdr1"), "hdr1")
+	miniBlocks := createLruCache(t, 7)
+	_ = miniBlocks.Put([]byte("mb1"), "mb1")
+	_ = miniBlocks.Put([]byte("mb2"), "mb2")
+
+	pools, err := dataPool.NewShardedDataPool(
+		txs,
+		createShardedData(t, 10),
+		createShardedData(t, 10),
+		headers,
+		&mock.Uint64SyncMapCacherStub{},
+		miniBlocks,
+		createLruCache(t, 3),
+		createLruCache(t, 4),
+		createLruCache(t, 9),
+	)
+	assert.Nil(t, err)
+
+	sizes := dataPool.GetShardPoolsSizes(pools)
+
+	assert.Equal(t, dataPool.CacheSize{NumItems: 3, Capacity: 10}, sizes.Transactions)
+	assert.Equal(t, dataPool.CacheSize{NumItems: 0, Capacity: 10}, sizes.UnsignedTransactions)
+	assert.Equal(t, dataPool.CacheSize{NumItems: 0, Capacity: 10}, sizes.RewardTransactions)
+	assert.Equal(t, dataPool.CacheSize{NumItems: 1, Capacity: 5}, sizes.Headers)
+	assert.Equal(t, dataPool.CacheSize{NumItems: 2, Capacity: 7}, sizes.MiniBlocks)
+	assert.Equal(t, dataPool.CacheSize{NumItems: 0, Capacity: 3}, sizes.PeerChangesBlocks)
+	assert.Equal(t, dataPool.CacheSize{NumItems: 0, Capacity: 4}, sizes.MetaBlocks)
+	assert.Equal(t, dataPool.CacheSize{NumItems: 0, Capacity: 9}, sizes.ValidatorInfo)
+}
+
+func TestGetMetaPoolsSizes_NilPoolsShouldReturnEmpty(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, dataPool.MetaPoolsSizes{}, dataPool.GetMetaPoolsSizes(nil))
+}
+
+func TestGetMetaPoolsSizes_ShouldReportItemsAndCapacities(t *testing.T) {
+	t.Parallel()
+
+	shardHeaders := createLruCache(t, 6)
+	_ = shardHeaders.Put([]byte("hdr1"), "hdr1")
+	_ = shardHeaders.Put([]byte("hdr2"), "hdr2")
+	uTxs := createShardedData(t, 8)
+	uTxs.AddData([]byte("scr1"), "scr1", "1")
+
+	pools, err := dataPool.NewMetaDataPool(
+		createLruCache(t, 2),
+		createLruCache(t, 3),
+		shardHeaders,
+		&mock.Uint64SyncMapCacherStub{},
+		createShardedData(t, 10),
+		uTxs,
+	)
+	assert.Nil(t, err)
+
+	sizes := dataPool.GetMetaPoolsSizes(pools)
+
+	assert.Equal(t, dataPool.CacheSize{NumItems: 0, Capacity: 2}, sizes.MetaBlocks)
+	assert.Equal(t, dataPool.CacheSize{NumItems: 0, Capacity: 3}, sizes.MiniBlocks)
+	assert.Equal(t, dataPool.CacheSize{NumItems: 2, Capacity: 6}, sizes.ShardHeaders)
+	assert.Equal(t, dataPool.CacheSize{NumItems: 0, Capacity: 10}, sizes.Transactions)
+	assert.Equal(t, dataPool.CacheSize{NumItems: 1, Capacity: 8}, sizes.UnsignedTransactions)
+}
@@ -14,6 +14,7 @@ type shardedDataPool struct {
 	headersNonces        dataRetriever.Uint64SyncMapCacher
 	miniBlocks           storage.Cacher
 	peerChangesBlocks    storage.Cacher
+	validatorInfo        storage.Cacher
 }
 
 // NewShardedDataPool creates a data pools holder object
@@ -26,6 +27,7 @@ func NewShardedDataPool(
 	miniBlocks storage.Cacher,
 	peerChangesBlocks storage.Cacher,
 	metaBlocks storage.Cacher,
+	validatorInfo storage.Cacher,
 ) (*shardedDataPool, error) {
 
 	if transactions == nil || transactions.IsInterfaceNil() {
@@ -52,6 +54,9 @@ func NewShardedDataPool(
 	if metaBlocks == nil || metaBlocks.IsInterfaceNil() {
 		return nil, dataRetriever.ErrNilMetaBlockPool
 	}
+	if validatorInfo == nil || validatorInfo.IsInterfaceNil() {
+		return nil, dataRetriever.ErrNilValidatorInfoPool
+	}
 
 	return &shardedDataPool{
 		transactions:         transactions,
@@ -62,6 +67,7 @@ func NewShardedDataPool(
 		miniBlocks:           miniBlocks,
 		peerChangesBlocks:    peerChangesBlocks,
 		metaBlocks:           metaBlocks,
+		validatorInfo:        validatorInfo,
 	}, nil
 }
 
@@ -106,6 +112,11 @@ func (tdp *shardedDataPool) MetaBlocks() storage.Cacher {
 	return tdp.metaBlocks
 }
 
+// ValidatorInfo returns the holder for validator info messages
+func (tdp *shardedDataPool) ValidatorInfo() storage.Cacher {
+	return tdp.validatorInfo
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (tdp *shardedDataPool) IsInterfaceNil() bool {
 	if tdp == nil {
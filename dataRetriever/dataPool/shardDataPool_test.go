@@ -23,6 +23,7 @@ func TestNewShardedDataPool_NilTransactionsShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		&mock.CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilTxDataPool, err)
@@ -41,6 +42,7 @@ func TestNewShardedDataPool_NilUnsignedTransactionsShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		&mock.CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilUnsignedTransactionPool, err)
@@ -59,6 +61,7 @@ func TestNewShardedDataPool_NilRewardTransactionsShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		&mock.CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilRewardTransactionPool, err)
@@ -77,6 +80,7 @@ func TestNewShardedDataPool_NilHeadersShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		&mock.CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilHeadersDataPool, err)
@@ -95,6 +99,7 @@ func TestNewShardedDataPool_NilHeaderNoncesShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		&mock.CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilHeadersNoncesDataPool, err)
@@ -113,6 +118,7 @@ func TestNewShardedDataPool_NilTxBlocksShouldErr(t *testing.T) {
 		nil,
 		&mock.CacherStub{},
 		&mock.CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilTxBlockDataPool, err)
@@ -131,6 +137,7 @@ func TestNewShardedDataPool_NilPeerBlocksShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		nil,
 		&mock.CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilPeerChangeBlockDataPool, err)
@@ -149,12 +156,32 @@ func TestNewShardedDataPool_NilMetaBlocksShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		nil,
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilMetaBlockPool, err)
 	assert.Nil(t, tdp)
 }
 
+func TestNewShardedDataPool_NilValidatorInfoShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tdp, err := dataPool.NewShardedDataPool(
+		&mock.ShardedDataStub{},
+		&mock.ShardedDataStub{},
+		&mock.ShardedDataStub{},
+		&mock.CacherStub{},
+		&mock.Uint64SyncMapCacherStub{},
+		&mock.CacherStub{},
+		&mock.CacherStub{},
+		&mock.CacherStub{},
+		nil,
+	)
+
+	assert.Equal(t, dataRetriever.ErrNilValidatorInfoPool, err)
+	assert.Nil(t, tdp)
+}
+
 func TestNewShardedDataPool_OkValsShouldWork(t *testing.T) {
 	transactions := &mock.ShardedDataStub{}
 	scResults := &mock.ShardedDataStub{}
@@ -164,6 +191,7 @@ func TestNewShardedDataPool_OkValsShouldWork(t *testing.T) {
 	txBlocks := &mock.CacherStub{}
 	peersBlock := &mock.CacherStub{}
 	metaChainBlocks := &mock.CacherStub{}
+	validatorInfo := &mock.CacherStub{}
 	tdp, err := dataPool.NewShardedDataPool(
 		transactions,
 		scResults,
@@ -173,6 +201,7 @@ func TestNewShardedDataPool_OkValsShouldWork(t *testing.T) {
 		txBlocks,
 		peersBlock,
 		metaChainBlocks,
+		validatorInfo,
 	)
 
 	assert.Nil(t, err)
@@ -185,5 +214,6 @@ func TestNewShardedDataPool_OkValsShouldWork(t *testing.T) {
 	assert.True(t, txBlocks == tdp.MiniBlocks())
 	assert.True(t, peersBlock == tdp.PeerChangesBlocks())
 	assert.True(t, metaChainBlocks == tdp.MetaBlocks())
+	assert.True(t, validatorInfo == tdp.ValidatorInfo())
 	assert.True(t, scResults == tdp.UnsignedTransactions())
 }
@@ -175,6 +175,8 @@ type ShardedDataCacherNotifier interface {
 	Clear()
 	ClearShardStore(cacheId string)
 	CreateShardStore(cacheId string)
+	Len() int
+	MaxSize() int
 }
 
 // ShardIdHashMap represents a map for shardId and hash
@@ -207,6 +209,7 @@ type PoolsHolder interface {
 	MiniBlocks() storage.Cacher
 	PeerChangesBlocks() storage.Cacher
 	MetaBlocks() storage.Cacher
+	ValidatorInfo() storage.Cacher
 	IsInterfaceNil() bool
 }
 
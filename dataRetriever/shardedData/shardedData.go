@@ -225,6 +225,25 @@ func (sd *shardedData) ClearShardStore(cacheId string) {
 	mp.Clear()
 }
 
+// Len returns the number of data items held in all the shard stores
+func (sd *shardedData) Len() int {
+	sd.mutShardedDataStore.RLock()
+	defer sd.mutShardedDataStore.RUnlock()
+
+	numItems := 0
+	for _, store := range sd.shardedDataStore {
+		numItems += store.DataStore.Len()
+	}
+
+	return numItems
+}
+
+// MaxSize returns the maximum number of data items each shard store can hold, as configured. The shard stores
+// are created on demand so the value is reported even if no shard store exists yet
+func (sd *shardedData) MaxSize() int {
+	return int(sd.cacherConfig.Size)
+}
+
 // RegisterHandler registers a new handler to be called when a new data is added
 func (sd *shardedData) RegisterHandler(handler func(key []byte)) {
 	if handler == nil {
@@ -315,3 +315,18 @@ func TestShardedData_SearchFirstDataFoundShouldRetResults(t *testing.T) {
 	assert.NotNil(t, value)
 	assert.True(t, ok)
 }
+
+func TestShardedData_LenShouldSumAllShardStoresAndMaxSizeShouldBePerStore(t *testing.T) {
+	t.Parallel()
+
+	sd, _ := shardedData.NewShardedData(defaultTestConfig)
+	assert.Equal(t, 0, sd.Len())
+	assert.Equal(t, int(defaultTestConfig.Size), sd.MaxSize())
+
+	sd.AddData([]byte("tx_hash1"), &transaction.Transaction{Nonce: 1}, "1")
+	sd.AddData([]byte("tx_hash2"), &transaction.Transaction{Nonce: 2}, "1")
+	sd.AddData([]byte("tx_hash3"), &transaction.Transaction{Nonce: 3}, "2")
+
+	assert.Equal(t, 3, sd.Len())
+	assert.Equal(t, int(defaultTestConfig.Size), sd.MaxSize())
+}
@@ -13,6 +13,7 @@ type PoolsHolderStub struct {
 	UnsignedTransactionsCalled func() dataRetriever.ShardedDataCacherNotifier
 	MiniBlocksCalled           func() storage.Cacher
 	MetaBlocksCalled           func() storage.Cacher
+	ValidatorInfoCalled        func() storage.Cacher
 }
 
 func (phs *PoolsHolderStub) Headers() storage.Cacher {
@@ -43,6 +44,10 @@ func (phs *PoolsHolderStub) UnsignedTransactions() dataRetriever.ShardedDataCach
 	return phs.UnsignedTransactionsCalled()
 }
 
+func (phs *PoolsHolderStub) ValidatorInfo() storage.Cacher {
+	return phs.ValidatorInfoCalled()
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (phs *PoolsHolderStub) IsInterfaceNil() bool {
 	if phs == nil {
@@ -17,6 +17,8 @@ type ShardedDataStub struct {
 	ClearShardStoreCalled         func(cacheId string)
 	RemoveSetOfDataFromPoolCalled func(keys [][]byte, destCacheId string)
 	CreateShardStoreCalled        func(destCacheId string)
+	LenCalled                     func() int
+	MaxSizeCalled                 func() int
 }
 
 func (sd *ShardedDataStub) RegisterHandler(handler func(key []byte)) {
@@ -67,6 +69,14 @@ func (sd *ShardedDataStub) CreateShardStore(cacheId string) {
 	sd.CreateShardStoreCalled(cacheId)
 }
 
+func (sd *ShardedDataStub) Len() int {
+	return sd.LenCalled()
+}
+
+func (sd *ShardedDataStub) MaxSize() int {
+	return sd.MaxSizeCalled()
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (sd *ShardedDataStub) IsInterfaceNil() bool {
 	if sd == nil {
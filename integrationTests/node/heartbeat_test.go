@@ -154,6 +154,7 @@ func createSender(messenger p2p.Messenger, topic string) (*heartbeat.Sender, cry
 		integrationTests.TestMarshalizer,
 		topic,
 		&sharding.OneShardCoordinator{},
+		&mock.BlockChainMock{},
 		version,
 		nodeName,
 	)
@@ -166,6 +167,9 @@ func createMonitor(maxDurationPeerUnresponsive time.Duration) *heartbeat.Monitor
 	monitor, _ := heartbeat.NewMonitor(
 		integrationTests.TestMarshalizer,
 		maxDurationPeerUnresponsive,
+		maxDurationPeerUnresponsive,
+		0,
+		0,
 		map[uint32][]string{0: {""}},
 		time.Now(),
 		&mock.MessageHandlerStub{
@@ -95,6 +95,19 @@ var ErrWrongTypeAssertion = errors.New("wrong type assertion: expected *block.He
 var ErrNegativeDurationInSecToConsiderUnresponsive = errors.New("value DurationInSecToConsiderUnresponsive is less" +
 	" than 1")
 
+// ErrNegativeDurationInSecToConsiderValidatorUnresponsive is raised when a negative value has been provided
+var ErrNegativeDurationInSecToConsiderValidatorUnresponsive = errors.New("value DurationInSecToConsiderValidatorUnresponsive" +
+	" is negative")
+
+// ErrNegativeGracePeriodInSec is raised when a negative value has been provided
+var ErrNegativeGracePeriodInSec = errors.New("value GracePeriodInSec is negative")
+
+// ErrNegativeSettlePeriodInSec is raised when a negative value has been provided
+var ErrNegativeSettlePeriodInSec = errors.New("value SettlePeriodInSec is negative")
+
+// ErrNegativeMaxAllowedTimeSkewInSec is raised when a negative value has been provided
+var ErrNegativeMaxAllowedTimeSkewInSec = errors.New("value MaxAllowedTimeSkewInSec is negative")
+
 // ErrNegativeMaxTimeToWaitBetweenBroadcastsInSec is raised when a value less than 1 has been provided
 var ErrNegativeMaxTimeToWaitBetweenBroadcastsInSec = errors.New("value MaxTimeToWaitBetweenBroadcastsInSec is less " +
 	"than 1")
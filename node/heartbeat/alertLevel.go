@@ -0,0 +1,35 @@
+package heartbeat
+
+// AlertLevel defines how serious the inactivity of a peer is
+type AlertLevel uint8
+
+const (
+	// AlertNone signals that the peer is active or was not found inactive often enough to raise an alert
+	AlertNone AlertLevel = iota
+	// AlertWarning signals that the peer was found inactive for at least the warning threshold
+	AlertWarning
+	// AlertCritical signals that the peer was found inactive for at least the critical threshold
+	AlertCritical
+)
+
+// DefaultWarningAlertThreshold is the default number of consecutive inactive computations after which
+// a peer is reported with a warning alert level
+const DefaultWarningAlertThreshold = 1
+
+// DefaultCriticalAlertThreshold is the default number of consecutive inactive computations after which
+// a peer is reported with a critical alert level
+const DefaultCriticalAlertThreshold = 5
+
+// String returns the human readable form of the alert level
+func (al AlertLevel) String() string {
+	switch al {
+	case AlertNone:
+		return "none"
+	case AlertWarning:
+		return "warning"
+	case AlertCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
@@ -29,12 +29,21 @@ var ErrNilDataToProcess = errors.New("nil data to process")
 // ErrInvalidMaxDurationPeerUnresponsive signals that the duration provided is invalid
 var ErrInvalidMaxDurationPeerUnresponsive = errors.New("invalid max duration to declare the peer unresponsive")
 
+// ErrInvalidMaxDurationValidatorUnresponsive signals that the duration provided for validators is invalid
+var ErrInvalidMaxDurationValidatorUnresponsive = errors.New("invalid max duration to declare the validator unresponsive")
+
+// ErrInvalidHysteresisDuration signals that a negative grace or settle period was provided
+var ErrInvalidHysteresisDuration = errors.New("invalid grace or settle period, should not be negative")
+
 // ErrNilAppStatusHandler defines the error for setting a nil AppStatusHandler
 var ErrNilAppStatusHandler = errors.New("nil AppStatusHandler")
 
 // ErrNilShardCoordinator signals that an operation has been attempted to or with a nil shard coordinator
 var ErrNilShardCoordinator = errors.New("nil shard coordinator")
 
+// ErrNilBlockChain signals that a nil block chain has been provided
+var ErrNilBlockChain = errors.New("nil block chain")
+
 // ErrNilTimer signals that a nil time getter handler has been provided
 var ErrNilTimer = errors.New("nil time getter handler")
 
@@ -58,3 +67,18 @@ var ErrUnmarshalGenesisTime = errors.New("monitor: can't unmarshal genesis time"
 
 // ErrMarshalGenesisTime signals that the marshaling of the genesis time didn't work
 var ErrMarshalGenesisTime = errors.New("monitor: can't marshal genesis time")
+
+// ErrPeerNotFound signals that the provided public key does not belong to a monitored peer
+var ErrPeerNotFound = errors.New("peer not found")
+
+// ErrInvalidAlertThresholds signals that the provided alert thresholds are invalid
+var ErrInvalidAlertThresholds = errors.New("invalid alert thresholds, warning should be positive and not greater than critical")
+
+// ErrInvalidUptimeWindow signals that a zero or negative uptime window was provided
+var ErrInvalidUptimeWindow = errors.New("invalid uptime window, should be positive")
+
+// ErrNilStatusChangeHandler signals that a nil status change handler was provided
+var ErrNilStatusChangeHandler = errors.New("nil status change handler")
+
+// ErrInvalidMaxAllowedTimeSkew signals that an invalid max allowed time skew was provided
+var ErrInvalidMaxAllowedTimeSkew = errors.New("invalid max allowed time skew, should be positive")
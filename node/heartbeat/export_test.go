@@ -39,15 +39,23 @@ func (m *Monitor) AddHeartbeatMessageToMap(hb *Heartbeat) {
 
 func NewHeartbeatMessageInfo(
 	maxDurationPeerUnresponsive time.Duration,
+	maxDurationValidatorUnresponsive time.Duration,
+	gracePeriod time.Duration,
+	settlePeriod time.Duration,
 	isValidator bool,
 	genesisTime time.Time,
 	timer Timer,
+	onStatusChange func(isActive bool),
 ) (*heartbeatMessageInfo, error) {
 	return newHeartbeatMessageInfo(
 		maxDurationPeerUnresponsive,
+		maxDurationValidatorUnresponsive,
+		gracePeriod,
+		settlePeriod,
 		isValidator,
 		genesisTime,
 		timer,
+		onStatusChange,
 	)
 }
 
@@ -67,6 +75,31 @@ func (hbmi *heartbeatMessageInfo) GetTotalDownTime() Duration {
 	return hbmi.totalDownTime
 }
 
+func (hbmi *heartbeatMessageInfo) GetMaxInactiveTime() Duration {
+	return hbmi.maxInactiveTime
+}
+
 func (hbmi *heartbeatMessageInfo) GetIsActive() bool {
 	return hbmi.isActive
 }
+
+func (hbmi *heartbeatMessageInfo) GetVersionNumber() string {
+	return hbmi.versionNumber
+}
+
+func (hbmi *heartbeatMessageInfo) ComputeActive(crtTime time.Time) {
+	hbmi.computeActive(crtTime)
+}
+
+func ProcessInBatch(infos []*heartbeatMessageInfo, maxConcurrency int, handler func(hbmi *heartbeatMessageInfo)) {
+	processInBatch(infos, maxConcurrency, handler)
+}
+
+type HeartbeatMessageInfoType = heartbeatMessageInfo
+
+func (m *Monitor) MaxAllowedTimeSkew() time.Duration {
+	m.mutMaxAllowedTimeSkew.RLock()
+	defer m.mutMaxAllowedTimeSkew.RUnlock()
+
+	return m.maxAllowedTimeSkew
+}
@@ -1,16 +1,25 @@
 package heartbeat
 
 import (
+	"encoding/json"
 	"sync"
 	"time"
+
+	"github.com/ElrondNetwork/elrond-go/marshal"
 )
 
+// arrivalIntervalSmoothingFactor is the weight of the newest sample in the average arrival interval
+const arrivalIntervalSmoothingFactor = 0.2
+
 // heartbeatMessageInfo retain the message info received from another node (identified by a public key)
 type heartbeatMessageInfo struct {
-	maxDurationPeerUnresponsive time.Duration
-	maxInactiveTime             Duration
-	totalUpTime                 Duration
-	totalDownTime               Duration
+	maxDurationPeerUnresponsive      time.Duration
+	maxDurationValidatorUnresponsive time.Duration
+	gracePeriod                      time.Duration
+	settlePeriod                     time.Duration
+	maxInactiveTime                  Duration
+	totalUpTime                      Duration
+	totalDownTime                    Duration
 
 	getTimeHandler     func() time.Time
 	timeStamp          time.Time
@@ -22,57 +31,209 @@ type heartbeatMessageInfo struct {
 	isValidator        bool
 	lastUptimeDowntime time.Time
 	genesisTime        time.Time
+	ignored            bool
+	lastKnownNonce     uint64
+	isNonceStalled     bool
+	numInactiveChecks  uint32
+	warningThreshold   uint32
+	criticalThreshold  uint32
+	responsiveSince    time.Time
+	bootTimestamp      int64
+	restartCount       uint32
+	uptimeWindow       *uptimeWindow
+	previousVersion    string
+	versionChangedAt   time.Time
+	lastArrival        time.Time
+	avgArrivalInterval time.Duration
+	onStatusChange     func(isActive bool)
 	updateMutex        sync.Mutex
 }
 
-// newHeartbeatMessageInfo returns a new instance of a heartbeatMessageInfo
+// newHeartbeatMessageInfo returns a new instance of a heartbeatMessageInfo. The maxDurationValidatorUnresponsive
+// is applied instead of maxDurationPeerUnresponsive when the peer is a validator and can not be greater than it.
+// A peer is declared inactive only after it was unresponsive for the max duration plus the grace period and
+// it is declared active again only after it was responsive for the settle period. The optional onStatusChange
+// handler is called once for each transition between active and inactive, while the peer's state is locked
 func newHeartbeatMessageInfo(
 	maxDurationPeerUnresponsive time.Duration,
+	maxDurationValidatorUnresponsive time.Duration,
+	gracePeriod time.Duration,
+	settlePeriod time.Duration,
 	isValidator bool,
 	genesisTime time.Time,
 	timer Timer,
+	onStatusChange func(isActive bool),
 ) (*heartbeatMessageInfo, error) {
 
 	if maxDurationPeerUnresponsive == 0 {
 		return nil, ErrInvalidMaxDurationPeerUnresponsive
 	}
+	if maxDurationValidatorUnresponsive == 0 || maxDurationValidatorUnresponsive > maxDurationPeerUnresponsive {
+		return nil, ErrInvalidMaxDurationValidatorUnresponsive
+	}
+	if gracePeriod < 0 || settlePeriod < 0 {
+		return nil, ErrInvalidHysteresisDuration
+	}
 	if timer == nil || timer.IsInterfaceNil() {
 		return nil, ErrNilTimer
 	}
 
 	hbmi := &heartbeatMessageInfo{
-		maxDurationPeerUnresponsive: maxDurationPeerUnresponsive,
-		maxInactiveTime:             Duration{0},
-		isActive:                    false,
-		receivedShardID:             uint32(0),
-		timeStamp:                   genesisTime,
-		lastUptimeDowntime:          timer.Now(),
-		totalUpTime:                 Duration{0},
-		totalDownTime:               Duration{0},
-		versionNumber:               "",
-		nodeDisplayName:             "",
-		isValidator:                 isValidator,
-		genesisTime:                 genesisTime,
-		getTimeHandler:              timer.Now,
+		maxDurationPeerUnresponsive:      maxDurationPeerUnresponsive,
+		maxDurationValidatorUnresponsive: maxDurationValidatorUnresponsive,
+		gracePeriod:                      gracePeriod,
+		settlePeriod:                     settlePeriod,
+		maxInactiveTime:                  Duration{0},
+		isActive:                         false,
+		receivedShardID:                  uint32(0),
+		timeStamp:                        genesisTime,
+		lastUptimeDowntime:               timer.Now(),
+		totalUpTime:                      Duration{0},
+		totalDownTime:                    Duration{0},
+		versionNumber:                    "",
+		nodeDisplayName:                  "",
+		isValidator:                      isValidator,
+		genesisTime:                      genesisTime,
+		getTimeHandler:                   timer.Now,
+		warningThreshold:                 DefaultWarningAlertThreshold,
+		criticalThreshold:                DefaultCriticalAlertThreshold,
+		uptimeWindow:                     newUptimeWindow(DefaultUptimeWindow),
+		onStatusChange:                   onStatusChange,
 	}
 
 	return hbmi, nil
 }
 
 func (hbmi *heartbeatMessageInfo) updateFields(crtTime time.Time) {
+	wasActive := hbmi.isActive
+	defer hbmi.notifyStatusChange(wasActive)
+
 	validDuration := computeValidDuration(crtTime, hbmi)
 	previousActive := hbmi.isActive && validDuration
-	hbmi.isActive = true
+	if !validDuration || hbmi.responsiveSince.IsZero() {
+		hbmi.responsiveSince = crtTime
+	}
+	//the settle period applies only when moving from inactive to active, an active peer stays active
+	hbmi.isActive = previousActive || hbmi.hasSettled(crtTime)
+	if hbmi.isActive {
+		hbmi.numInactiveChecks = 0
+	}
 
 	hbmi.updateTimes(crtTime, previousActive)
 }
 
 func (hbmi *heartbeatMessageInfo) computeActive(crtTime time.Time) {
 	hbmi.updateMutex.Lock()
+	wasActive := hbmi.isActive
 	validDuration := computeValidDuration(crtTime, hbmi)
-	hbmi.isActive = hbmi.isActive && validDuration
-	hbmi.updateTimes(crtTime, hbmi.isActive)
+	if !validDuration {
+		hbmi.responsiveSince = time.Time{}
+	}
+	hbmi.isActive = validDuration && (hbmi.isActive || hbmi.hasSettled(crtTime))
+	hbmi.updateTimes(crtTime, wasActive && hbmi.isActive)
+	hbmi.updateInactiveChecks()
+	hbmi.notifyStatusChange(wasActive)
+	hbmi.updateMutex.Unlock()
+}
+
+func (hbmi *heartbeatMessageInfo) hasSettled(crtTime time.Time) bool {
+	if hbmi.responsiveSince.IsZero() {
+		return false
+	}
+
+	return crtTime.Sub(hbmi.responsiveSince) >= hbmi.settlePeriod
+}
+
+func (hbmi *heartbeatMessageInfo) notifyStatusChange(wasActive bool) {
+	if hbmi.onStatusChange == nil || wasActive == hbmi.isActive {
+		return
+	}
+
+	hbmi.onStatusChange(hbmi.isActive)
+}
+
+// updateInactiveChecks counts the consecutive computations that found the peer inactive. A peer that is still
+// heartbeating while waiting for its settle period is responsive and is not counted
+func (hbmi *heartbeatMessageInfo) updateInactiveChecks() {
+	isSettling := !hbmi.responsiveSince.IsZero()
+	if hbmi.isActive || isSettling {
+		hbmi.numInactiveChecks = 0
+		return
+	}
+
+	hbmi.numInactiveChecks++
+}
+
+// SetAlertThresholds sets the number of consecutive inactive computations after which the peer
+// is reported with a warning, respectively a critical alert level
+func (hbmi *heartbeatMessageInfo) SetAlertThresholds(warningThreshold uint32, criticalThreshold uint32) error {
+	if warningThreshold == 0 || criticalThreshold < warningThreshold {
+		return ErrInvalidAlertThresholds
+	}
+
+	hbmi.updateMutex.Lock()
+	hbmi.warningThreshold = warningThreshold
+	hbmi.criticalThreshold = criticalThreshold
 	hbmi.updateMutex.Unlock()
+
+	return nil
+}
+
+// AlertLevel returns the alert level corresponding to the number of consecutive inactive computations.
+// Ignored peers never raise alerts
+func (hbmi *heartbeatMessageInfo) AlertLevel() AlertLevel {
+	hbmi.updateMutex.Lock()
+	defer hbmi.updateMutex.Unlock()
+
+	if hbmi.ignored {
+		return AlertNone
+	}
+	if hbmi.numInactiveChecks >= hbmi.criticalThreshold {
+		return AlertCritical
+	}
+	if hbmi.numInactiveChecks >= hbmi.warningThreshold {
+		return AlertWarning
+	}
+
+	return AlertNone
+}
+
+// BatchComputeActive recomputes the active state of all provided records at the given time, using at most
+// maxConcurrency go routines. A maxConcurrency lower than 1 is treated as 1
+func BatchComputeActive(infos []*heartbeatMessageInfo, now time.Time, maxConcurrency int) {
+	processInBatch(infos, maxConcurrency, func(hbmi *heartbeatMessageInfo) {
+		hbmi.computeActive(now)
+	})
+}
+
+func processInBatch(infos []*heartbeatMessageInfo, maxConcurrency int, handler func(hbmi *heartbeatMessageInfo)) {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	if maxConcurrency > len(infos) {
+		maxConcurrency = len(infos)
+	}
+
+	chInfos := make(chan *heartbeatMessageInfo, len(infos))
+	for _, hbmi := range infos {
+		if hbmi == nil {
+			continue
+		}
+		chInfos <- hbmi
+	}
+	close(chInfos)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(maxConcurrency)
+	for i := 0; i < maxConcurrency; i++ {
+		go func() {
+			for hbmi := range chInfos {
+				handler(hbmi)
+			}
+			wg.Done()
+		}()
+	}
+	wg.Wait()
 }
 
 func (hbmi *heartbeatMessageInfo) updateTimes(crtTime time.Time, previousActive bool) {
@@ -86,10 +247,93 @@ func (hbmi *heartbeatMessageInfo) updateTimes(crtTime time.Time, previousActive
 func computeValidDuration(crtTime time.Time, hbmi *heartbeatMessageInfo) bool {
 	crtDuration := crtTime.Sub(hbmi.timeStamp)
 	crtDuration = maxDuration(0, crtDuration)
-	validDuration := crtDuration <= hbmi.maxDurationPeerUnresponsive
+	validDuration := crtDuration <= hbmi.maxDurationUnresponsive()+hbmi.gracePeriod
 	return validDuration
 }
 
+// maxDurationUnresponsive returns the inactivity threshold corresponding to the peer's role
+func (hbmi *heartbeatMessageInfo) maxDurationUnresponsive() time.Duration {
+	if hbmi.isValidator {
+		return hbmi.maxDurationValidatorUnresponsive
+	}
+
+	return hbmi.maxDurationPeerUnresponsive
+}
+
+// UptimeAt returns the total up and down durations the record would have if the active state was
+// recomputed at the provided time. The record is not modified
+func (hbmi *heartbeatMessageInfo) UptimeAt(t time.Time) (time.Duration, time.Duration) {
+	hbmi.updateMutex.Lock()
+	defer hbmi.updateMutex.Unlock()
+
+	up := hbmi.totalUpTime.Duration
+	down := hbmi.totalDownTime.Duration
+	if t.Sub(hbmi.genesisTime) < 0 {
+		return up, down
+	}
+
+	isActive := hbmi.isActive && computeValidDuration(t, hbmi)
+	lastUptimeDowntime := hbmi.lastUptimeDowntime
+	if lastUptimeDowntime.Sub(hbmi.genesisTime) < 0 {
+		lastUptimeDowntime = hbmi.genesisTime
+	}
+
+	lastDuration := maxDuration(0, t.Sub(lastUptimeDowntime))
+	if isActive {
+		up += lastDuration
+	} else {
+		down += lastDuration
+	}
+
+	return up, down
+}
+
+// SetUptimeWindow sets the length of the recent history used by GetUptimePercentageWindow. The already
+// recorded history is discarded
+func (hbmi *heartbeatMessageInfo) SetUptimeWindow(window time.Duration) error {
+	if window <= 0 {
+		return ErrInvalidUptimeWindow
+	}
+
+	hbmi.updateMutex.Lock()
+	hbmi.uptimeWindow = newUptimeWindow(window)
+	hbmi.updateMutex.Unlock()
+
+	return nil
+}
+
+// GetUptimePercentageWindow returns the percentage of time the peer was up during the configured uptime
+// window ending now. It returns 0 if no history was recorded in the window
+func (hbmi *heartbeatMessageInfo) GetUptimePercentageWindow() float64 {
+	hbmi.updateMutex.Lock()
+	defer hbmi.updateMutex.Unlock()
+
+	if hbmi.uptimeWindow == nil {
+		return 0
+	}
+
+	crtTime := hbmi.getTimeHandler()
+	up, down := hbmi.uptimeWindow.upAndDown(crtTime)
+
+	lastUptimeDowntime := hbmi.lastUptimeDowntime
+	if lastUptimeDowntime.Sub(hbmi.genesisTime) < 0 {
+		lastUptimeDowntime = hbmi.genesisTime
+	}
+	pendingDuration := overlappingDuration(lastUptimeDowntime, crtTime, crtTime.Add(-hbmi.uptimeWindow.window), crtTime)
+	if hbmi.isActive && computeValidDuration(crtTime, hbmi) {
+		up += pendingDuration
+	} else {
+		down += pendingDuration
+	}
+
+	total := up + down
+	if total == 0 {
+		return 0
+	}
+
+	return float64(up) * 100 / float64(total)
+}
+
 // Will update the total time a node was up and down
 func (hbmi *heartbeatMessageInfo) updateUpAndDownTime(previousActive bool, crtTime time.Time) {
 	if hbmi.lastUptimeDowntime.Sub(hbmi.genesisTime) < 0 {
@@ -99,29 +343,157 @@ func (hbmi *heartbeatMessageInfo) updateUpAndDownTime(previousActive bool, crtTi
 	lastDuration := crtTime.Sub(hbmi.lastUptimeDowntime)
 	lastDuration = maxDuration(0, lastDuration)
 
-	if previousActive && hbmi.isActive {
+	isUp := previousActive && hbmi.isActive
+	if isUp {
 		hbmi.totalUpTime.Duration += lastDuration
 	} else {
 		hbmi.totalDownTime.Duration += lastDuration
 	}
+	if hbmi.uptimeWindow != nil {
+		hbmi.uptimeWindow.add(hbmi.lastUptimeDowntime, crtTime, isUp)
+	}
 
 	hbmi.lastUptimeDowntime = crtTime
 }
 
-// HeartbeatReceived processes a new message arrived from a peer
+// HeartbeatReceived processes a new message arrived from a peer. A zero nonce means that the
+// message does not carry the sender's current block nonce while a zero boot timestamp means that
+// the message does not carry the moment the sender was started
 func (hbmi *heartbeatMessageInfo) HeartbeatReceived(
 	computedShardID uint32,
 	receivedshardID uint32,
 	version string,
 	nodeDisplayName string,
+	nonce uint64,
+	bootTimestamp int64,
 ) {
 	crtTime := hbmi.getTimeHandler()
 	hbmi.updateFields(crtTime)
 	hbmi.computedShardID = computedShardID
 	hbmi.receivedShardID = receivedshardID
 	hbmi.timeStamp = crtTime
-	hbmi.versionNumber = version
+	hbmi.updateAverageArrivalInterval(crtTime)
+	hbmi.updateVersion(version, crtTime)
 	hbmi.nodeDisplayName = nodeDisplayName
+	hbmi.updateNonce(nonce)
+	hbmi.updateBootTimestamp(bootTimestamp)
+}
+
+func (hbmi *heartbeatMessageInfo) updateAverageArrivalInterval(crtTime time.Time) {
+	isFirstArrival := hbmi.lastArrival.IsZero()
+	interval := maxDuration(0, crtTime.Sub(hbmi.lastArrival))
+	hbmi.lastArrival = crtTime
+	if isFirstArrival {
+		return
+	}
+
+	if hbmi.avgArrivalInterval == 0 {
+		hbmi.avgArrivalInterval = interval
+		return
+	}
+
+	hbmi.avgArrivalInterval = time.Duration(arrivalIntervalSmoothingFactor*float64(interval) +
+		(1-arrivalIntervalSmoothingFactor)*float64(hbmi.avgArrivalInterval))
+}
+
+// AverageArrivalInterval returns the exponentially weighted moving average of the intervals between
+// two consecutive heartbeats received from the peer. Zero is returned until at least two heartbeats arrived
+func (hbmi *heartbeatMessageInfo) AverageArrivalInterval() time.Duration {
+	hbmi.updateMutex.Lock()
+	defer hbmi.updateMutex.Unlock()
+
+	return hbmi.avgArrivalInterval
+}
+
+func (hbmi *heartbeatMessageInfo) updateVersion(version string, crtTime time.Time) {
+	if version == hbmi.versionNumber {
+		return
+	}
+
+	if len(hbmi.versionNumber) > 0 {
+		hbmi.previousVersion = hbmi.versionNumber
+		hbmi.versionChangedAt = crtTime
+	}
+	hbmi.versionNumber = version
+}
+
+// PreviousVersion returns the version the peer reported before its last version change
+func (hbmi *heartbeatMessageInfo) PreviousVersion() string {
+	hbmi.updateMutex.Lock()
+	defer hbmi.updateMutex.Unlock()
+
+	return hbmi.previousVersion
+}
+
+// VersionChangedAt returns the moment the peer was seen reporting a different version than before.
+// The zero time is returned if the peer never changed its version
+func (hbmi *heartbeatMessageInfo) VersionChangedAt() time.Time {
+	hbmi.updateMutex.Lock()
+	defer hbmi.updateMutex.Unlock()
+
+	return hbmi.versionChangedAt
+}
+
+func (hbmi *heartbeatMessageInfo) updateBootTimestamp(bootTimestamp int64) {
+	if bootTimestamp == 0 {
+		return
+	}
+
+	hasPreviousBootTimestamp := hbmi.bootTimestamp != 0
+	if hasPreviousBootTimestamp && bootTimestamp != hbmi.bootTimestamp {
+		hbmi.restartCount++
+	}
+	hbmi.bootTimestamp = bootTimestamp
+}
+
+// RestartCount returns how many times the peer was detected as restarted
+func (hbmi *heartbeatMessageInfo) RestartCount() uint32 {
+	hbmi.updateMutex.Lock()
+	defer hbmi.updateMutex.Unlock()
+
+	return hbmi.restartCount
+}
+
+func (hbmi *heartbeatMessageInfo) updateNonce(nonce uint64) {
+	if nonce == 0 {
+		return
+	}
+
+	hasPreviousNonce := hbmi.lastKnownNonce > 0
+	hbmi.isNonceStalled = hasPreviousNonce && nonce <= hbmi.lastKnownNonce
+	hbmi.lastKnownNonce = nonce
+}
+
+// LastKnownNonce returns the latest block nonce reported by the peer
+func (hbmi *heartbeatMessageInfo) LastKnownNonce() uint64 {
+	hbmi.updateMutex.Lock()
+	defer hbmi.updateMutex.Unlock()
+
+	return hbmi.lastKnownNonce
+}
+
+// IsNonceStalled returns true if the block nonce reported in the last heartbeat did not advance
+// compared to the one reported in the previous heartbeat
+func (hbmi *heartbeatMessageInfo) IsNonceStalled() bool {
+	hbmi.updateMutex.Lock()
+	defer hbmi.updateMutex.Unlock()
+
+	return hbmi.isNonceStalled
+}
+
+// SetIgnored marks the peer as ignored (or not) so that it will be skipped by the aggregated metrics
+func (hbmi *heartbeatMessageInfo) SetIgnored(ignored bool) {
+	hbmi.updateMutex.Lock()
+	hbmi.ignored = ignored
+	hbmi.updateMutex.Unlock()
+}
+
+// IsIgnored returns true if the peer was marked as ignored
+func (hbmi *heartbeatMessageInfo) IsIgnored() bool {
+	hbmi.updateMutex.Lock()
+	defer hbmi.updateMutex.Unlock()
+
+	return hbmi.ignored
 }
 
 func (hbmi *heartbeatMessageInfo) updateMaxInactiveTimeDuration(currentTime time.Time) {
@@ -143,3 +515,111 @@ func maxDuration(first, second time.Duration) time.Duration {
 
 	return second
 }
+
+// MarshalForStorage serializes the durable state of the peer, such as the uptime accounting, so that it
+// can be restored after a restart with LoadFromStorage
+func (hbmi *heartbeatMessageInfo) MarshalForStorage(marshalizer marshal.Marshalizer) ([]byte, error) {
+	if marshalizer == nil || marshalizer.IsInterfaceNil() {
+		return nil, ErrNilMarshalizer
+	}
+
+	hbmi.updateMutex.Lock()
+	hbDTO := hbmi.toStorageDTO()
+	hbmi.updateMutex.Unlock()
+
+	return marshalizer.Marshal(&hbDTO)
+}
+
+// LoadFromStorage restores the durable state of the peer from data produced by MarshalForStorage. The
+// durations, the time handler and the genesis time of the instance are kept
+func (hbmi *heartbeatMessageInfo) LoadFromStorage(marshalizer marshal.Marshalizer, buff []byte) error {
+	if marshalizer == nil || marshalizer.IsInterfaceNil() {
+		return ErrNilMarshalizer
+	}
+
+	hbDTO := &HeartbeatDTO{}
+	err := marshalizer.Unmarshal(hbDTO, buff)
+	if err != nil {
+		return err
+	}
+
+	hbmi.updateMutex.Lock()
+	hbmi.loadStorageDTO(*hbDTO)
+	hbmi.updateMutex.Unlock()
+
+	return nil
+}
+
+// toStorageDTO returns the durable state of the peer. The caller should hold the updateMutex
+func (hbmi *heartbeatMessageInfo) toStorageDTO() HeartbeatDTO {
+	return HeartbeatDTO{
+		TimeStamp:          hbmi.timeStamp,
+		MaxInactiveTime:    hbmi.maxInactiveTime,
+		IsActive:           hbmi.isActive,
+		ReceivedShardID:    hbmi.receivedShardID,
+		ComputedShardID:    hbmi.computedShardID,
+		TotalUpTime:        hbmi.totalUpTime,
+		TotalDownTime:      hbmi.totalDownTime,
+		VersionNumber:      hbmi.versionNumber,
+		IsValidator:        hbmi.isValidator,
+		NodeDisplayName:    hbmi.nodeDisplayName,
+		LastUptimeDowntime: hbmi.lastUptimeDowntime,
+		GenesisTime:        hbmi.genesisTime,
+		BootTimestamp:      hbmi.bootTimestamp,
+		RestartCount:       hbmi.restartCount,
+		PreviousVersion:    hbmi.previousVersion,
+		VersionChangedAt:   hbmi.versionChangedAt,
+	}
+}
+
+// loadStorageDTO restores the durable state of the peer. As the time passed since the state was saved is
+// not known to be up or down time, the accounting restarts from the current time. The caller should hold
+// the updateMutex
+func (hbmi *heartbeatMessageInfo) loadStorageDTO(hbDTO HeartbeatDTO) {
+	crtTime := hbmi.getTimeHandler()
+
+	hbmi.isValidator = hbDTO.IsValidator
+	hbmi.maxInactiveTime = hbDTO.MaxInactiveTime
+	hbmi.timeStamp = hbDTO.TimeStamp
+	hbmi.isActive = crtTime.Sub(hbDTO.LastUptimeDowntime) <= hbmi.maxDurationUnresponsive()
+	hbmi.totalUpTime = hbDTO.TotalUpTime
+	hbmi.totalDownTime = hbDTO.TotalDownTime
+	hbmi.receivedShardID = hbDTO.ReceivedShardID
+	hbmi.computedShardID = hbDTO.ComputedShardID
+	hbmi.versionNumber = hbDTO.VersionNumber
+	hbmi.nodeDisplayName = hbDTO.NodeDisplayName
+	hbmi.lastUptimeDowntime = crtTime
+	hbmi.bootTimestamp = hbDTO.BootTimestamp
+	hbmi.restartCount = hbDTO.RestartCount
+	hbmi.previousVersion = hbDTO.PreviousVersion
+	hbmi.versionChangedAt = hbDTO.VersionChangedAt
+}
+
+// ToExportDTO returns the peer's state in the form used by external monitoring tools
+func (hbmi *heartbeatMessageInfo) ToExportDTO() HeartbeatExportDTO {
+	hbmi.updateMutex.Lock()
+	defer hbmi.updateMutex.Unlock()
+
+	return HeartbeatExportDTO{
+		TimeStamp:          hbmi.timeStamp,
+		MaxInactiveTime:    int(hbmi.maxInactiveTime.Seconds()),
+		TotalUpTime:        int(hbmi.totalUpTime.Seconds()),
+		TotalDownTime:      int(hbmi.totalDownTime.Seconds()),
+		IsActive:           hbmi.isActive,
+		ReceivedShardID:    hbmi.receivedShardID,
+		ComputedShardID:    hbmi.computedShardID,
+		VersionNumber:      hbmi.versionNumber,
+		NodeDisplayName:    hbmi.nodeDisplayName,
+		IsValidator:        hbmi.isValidator,
+		LastUptimeDowntime: hbmi.lastUptimeDowntime,
+		GenesisTime:        hbmi.genesisTime,
+		RestartCount:       hbmi.restartCount,
+		PreviousVersion:    hbmi.previousVersion,
+		VersionChangedAt:   hbmi.versionChangedAt,
+	}
+}
+
+// MarshalJSON is called when a json marshal is triggered on the peer's state
+func (hbmi *heartbeatMessageInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hbmi.ToExportDTO())
+}
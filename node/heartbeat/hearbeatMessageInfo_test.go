@@ -1,6 +1,8 @@
 package heartbeat_test
 
 import (
+	"encoding/json"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -15,24 +17,104 @@ func TestNewHeartbeatMessageInfo_InvalidDurationShouldErr(t *testing.T) {
 	t.Parallel()
 
 	hbmi, err := heartbeat.NewHeartbeatMessageInfo(
+		0,
+		0,
+		0,
 		0,
 		false,
 		time.Time{},
 		&mock.MockTimer{},
+		nil,
 	)
 
 	assert.Nil(t, hbmi)
 	assert.Equal(t, heartbeat.ErrInvalidMaxDurationPeerUnresponsive, err)
 }
 
+func TestNewHeartbeatMessageInfo_InvalidValidatorDurationShouldErr(t *testing.T) {
+	t.Parallel()
+
+	hbmi, err := heartbeat.NewHeartbeatMessageInfo(
+		1,
+		0,
+		0,
+		0,
+		false,
+		time.Time{},
+		&mock.MockTimer{},
+		nil,
+	)
+
+	assert.Nil(t, hbmi)
+	assert.Equal(t, heartbeat.ErrInvalidMaxDurationValidatorUnresponsive, err)
+}
+
+func TestNewHeartbeatMessageInfo_ValidatorDurationGreaterThanPeerDurationShouldErr(t *testing.T) {
+	t.Parallel()
+
+	hbmi, err := heartbeat.NewHeartbeatMessageInfo(
+		1,
+		2,
+		0,
+		0,
+		false,
+		time.Time{},
+		&mock.MockTimer{},
+		nil,
+	)
+
+	assert.Nil(t, hbmi)
+	assert.Equal(t, heartbeat.ErrInvalidMaxDurationValidatorUnresponsive, err)
+}
+
+func TestNewHeartbeatMessageInfo_NegativeGracePeriodShouldErr(t *testing.T) {
+	t.Parallel()
+
+	hbmi, err := heartbeat.NewHeartbeatMessageInfo(
+		1,
+		1,
+		-1,
+		0,
+		false,
+		time.Time{},
+		&mock.MockTimer{},
+		nil,
+	)
+
+	assert.Nil(t, hbmi)
+	assert.Equal(t, heartbeat.ErrInvalidHysteresisDuration, err)
+}
+
+func TestNewHeartbeatMessageInfo_NegativeSettlePeriodShouldErr(t *testing.T) {
+	t.Parallel()
+
+	hbmi, err := heartbeat.NewHeartbeatMessageInfo(
+		1,
+		1,
+		0,
+		-1,
+		false,
+		time.Time{},
+		&mock.MockTimer{},
+		nil,
+	)
+
+	assert.Nil(t, hbmi)
+	assert.Equal(t, heartbeat.ErrInvalidHysteresisDuration, err)
+}
+
 func TestNewHeartbeatMessageInfo_NilGetTimeHandlerShouldErr(t *testing.T) {
 	t.Parallel()
 
 	hbmi, err := heartbeat.NewHeartbeatMessageInfo(
 		1,
+		1,
+		0,
+		0,
 		false,
 		time.Time{},
 		nil,
+		nil,
 	)
 
 	assert.Nil(t, hbmi)
@@ -44,9 +126,13 @@ func TestNewHeartbeatMessageInfo_OkValsShouldWork(t *testing.T) {
 
 	hbmi, err := heartbeat.NewHeartbeatMessageInfo(
 		1,
+		1,
+		0,
+		0,
 		false,
 		time.Time{},
 		&mock.MockTimer{},
+		nil,
 	)
 
 	assert.NotNil(t, hbmi)
@@ -63,9 +149,13 @@ func TestHeartbeatMessageInfo_HeartbeatReceivedShouldUpdate(t *testing.T) {
 
 	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
 		10*time.Second,
+		10*time.Second,
+		0,
+		0,
 		false,
 		genesisTime,
 		mockTimer,
+		nil,
 	)
 
 	assert.Equal(t, genesisTime, hbmi.GetTimeStamp())
@@ -73,13 +163,13 @@ func TestHeartbeatMessageInfo_HeartbeatReceivedShouldUpdate(t *testing.T) {
 	mockTimer.IncrementSeconds(1)
 
 	expectedTime := time.Unix(1, 0)
-	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined")
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
 	assert.Equal(t, expectedTime, hbmi.GetTimeStamp())
 	assert.Equal(t, uint32(0), hbmi.GetReceiverShardId())
 
 	mockTimer.IncrementSeconds(1)
 	expectedTime = time.Unix(2, 0)
-	hbmi.HeartbeatReceived(uint32(0), uint32(1), "v0.1", "undefined")
+	hbmi.HeartbeatReceived(uint32(0), uint32(1), "v0.1", "undefined", 0, 0)
 	assert.Equal(t, expectedTime, hbmi.GetTimeStamp())
 	assert.Equal(t, uint32(1), hbmi.GetReceiverShardId())
 }
@@ -91,9 +181,13 @@ func TestHeartbeatMessageInfo_HeartbeatUpdateFieldsShouldWork(t *testing.T) {
 	genesisTime := mockTimer.Now()
 	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
 		100*time.Second,
+		100*time.Second,
+		0,
+		0,
 		false,
 		genesisTime,
 		mockTimer,
+		nil,
 	)
 
 	assert.Equal(t, genesisTime, hbmi.GetTimeStamp())
@@ -103,7 +197,7 @@ func TestHeartbeatMessageInfo_HeartbeatUpdateFieldsShouldWork(t *testing.T) {
 	expectedTime := time.Unix(1, 0)
 	expectedUptime := time.Duration(0)
 	expectedDownTime := time.Duration(1 * time.Second)
-	hbmi.HeartbeatReceived(uint32(0), uint32(3), "v0.1", "undefined")
+	hbmi.HeartbeatReceived(uint32(0), uint32(3), "v0.1", "undefined", 0, 0)
 	assert.Equal(t, expectedTime, hbmi.GetTimeStamp())
 	assert.Equal(t, true, hbmi.GetIsActive())
 	assert.Equal(t, expectedUptime, hbmi.GetTotalUpTime().Duration)
@@ -117,18 +211,22 @@ func TestHeartbeatMessageInfo_HeartbeatShouldUpdateUpDownTime(t *testing.T) {
 	genesisTime := mockTimer.Now()
 	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
 		100*time.Second,
+		100*time.Second,
+		0,
+		0,
 		false,
 		genesisTime,
 		mockTimer,
+		nil,
 	)
 
 	assert.Equal(t, genesisTime, hbmi.GetTimeStamp())
 
 	// send heartbeat twice in order to calculate the duration between thm
 	mockTimer.IncrementSeconds(1)
-	hbmi.HeartbeatReceived(uint32(0), uint32(1), "v0.1", "undefined")
+	hbmi.HeartbeatReceived(uint32(0), uint32(1), "v0.1", "undefined", 0, 0)
 	mockTimer.IncrementSeconds(1)
-	hbmi.HeartbeatReceived(uint32(0), uint32(2), "v0.1", "undefined")
+	hbmi.HeartbeatReceived(uint32(0), uint32(2), "v0.1", "undefined", 0, 0)
 
 	expectedDownDuration := time.Duration(1 * time.Second)
 	expectedUpDuration := time.Duration(1 * time.Second)
@@ -145,18 +243,22 @@ func TestHeartbeatMessageInfo_HeartbeatLongerDurationThanMaxShouldUpdateDownTime
 	genesisTime := mockTimer.Now()
 	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
 		500*time.Millisecond,
+		500*time.Millisecond,
+		0,
+		0,
 		false,
 		genesisTime,
 		mockTimer,
+		nil,
 	)
 
 	assert.Equal(t, genesisTime, hbmi.GetTimeStamp())
 
 	// send heartbeat twice in order to calculate the duration between thm
 	mockTimer.IncrementSeconds(1)
-	hbmi.HeartbeatReceived(uint32(0), uint32(1), "v0.1", "undefined")
+	hbmi.HeartbeatReceived(uint32(0), uint32(1), "v0.1", "undefined", 0, 0)
 	mockTimer.IncrementSeconds(1)
-	hbmi.HeartbeatReceived(uint32(0), uint32(2), "v0.1", "undefined")
+	hbmi.HeartbeatReceived(uint32(0), uint32(2), "v0.1", "undefined", 0, 0)
 
 	expectedDownDuration := time.Duration(2 * time.Second)
 	expectedUpDuration := time.Duration(0)
@@ -173,18 +275,22 @@ func TestHeartbeatMessageInfo_HeartbeatBeforeGenesisShouldNotUpdateUpDownTime(t
 	genesisTime := time.Unix(5, 0)
 	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
 		100*time.Second,
+		100*time.Second,
+		0,
+		0,
 		false,
 		genesisTime,
 		mockTimer,
+		nil,
 	)
 
 	assert.Equal(t, genesisTime, hbmi.GetTimeStamp())
 
 	// send heartbeat twice in order to calculate the duration between thm
 	mockTimer.IncrementSeconds(1)
-	hbmi.HeartbeatReceived(uint32(0), uint32(1), "v0.1", "undefined")
+	hbmi.HeartbeatReceived(uint32(0), uint32(1), "v0.1", "undefined", 0, 0)
 	mockTimer.IncrementSeconds(1)
-	hbmi.HeartbeatReceived(uint32(0), uint32(2), "v0.1", "undefined")
+	hbmi.HeartbeatReceived(uint32(0), uint32(2), "v0.1", "undefined", 0, 0)
 
 	expectedDuration := time.Duration(0)
 	assert.Equal(t, expectedDuration, hbmi.GetTotalDownTime().Duration)
@@ -200,14 +306,18 @@ func TestHeartbeatMessageInfo_HeartbeatEqualGenesisShouldHaveUpDownTimeZero(t *t
 	genesisTime := time.Unix(1, 0)
 	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
 		100*time.Second,
+		100*time.Second,
+		0,
+		0,
 		false,
 		genesisTime,
 		mockTimer,
+		nil,
 	)
 
 	assert.Equal(t, genesisTime, hbmi.GetTimeStamp())
 	mockTimer.IncrementSeconds(1)
-	hbmi.HeartbeatReceived(uint32(0), uint32(1), "v0.1", "undefined")
+	hbmi.HeartbeatReceived(uint32(0), uint32(1), "v0.1", "undefined", 0, 0)
 
 	expectedDuration := time.Duration(0)
 	assert.Equal(t, expectedDuration, hbmi.GetTotalUpTime().Duration)
@@ -215,3 +325,1066 @@ func TestHeartbeatMessageInfo_HeartbeatEqualGenesisShouldHaveUpDownTimeZero(t *t
 	expectedTime := time.Unix(1, 0)
 	assert.Equal(t, expectedTime, hbmi.GetTimeStamp())
 }
+
+//------- computeActive
+
+func TestHeartbeatMessageInfo_ComputeActiveShouldUseValidatorThreshold(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	genesisTime := mockTimer.Now()
+	validatorHbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		3*time.Second,
+		0,
+		0,
+		true,
+		genesisTime,
+		mockTimer,
+		nil,
+	)
+	observerHbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		3*time.Second,
+		0,
+		0,
+		false,
+		genesisTime,
+		mockTimer,
+		nil,
+	)
+
+	mockTimer.IncrementSeconds(1)
+	validatorHbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	observerHbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+
+	mockTimer.IncrementSeconds(3)
+	validatorHbmi.ComputeActive(mockTimer.Now())
+	observerHbmi.ComputeActive(mockTimer.Now())
+	assert.True(t, validatorHbmi.GetIsActive())
+	assert.True(t, observerHbmi.GetIsActive())
+
+	mockTimer.IncrementSeconds(1)
+	validatorHbmi.ComputeActive(mockTimer.Now())
+	observerHbmi.ComputeActive(mockTimer.Now())
+	assert.False(t, validatorHbmi.GetIsActive())
+	assert.True(t, observerHbmi.GetIsActive())
+
+	mockTimer.IncrementSeconds(6)
+	observerHbmi.ComputeActive(mockTimer.Now())
+	assert.True(t, observerHbmi.GetIsActive())
+
+	mockTimer.IncrementSeconds(1)
+	observerHbmi.ComputeActive(mockTimer.Now())
+	assert.False(t, observerHbmi.GetIsActive())
+}
+
+//------- SetIgnored
+
+func TestHeartbeatMessageInfo_SetIgnoredShouldWork(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+
+	assert.False(t, hbmi.IsIgnored())
+
+	hbmi.SetIgnored(true)
+	assert.True(t, hbmi.IsIgnored())
+
+	hbmi.SetIgnored(false)
+	assert.False(t, hbmi.IsIgnored())
+}
+
+//------- BatchComputeActive
+
+func TestBatchComputeActive_ShouldUpdateAllRecords(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	genesisTime := mockTimer.Now()
+	numRecords := 20
+	infos := make([]*heartbeat.HeartbeatMessageInfoType, numRecords)
+	for i := 0; i < numRecords; i++ {
+		infos[i], _ = heartbeat.NewHeartbeatMessageInfo(
+			5*time.Second,
+			5*time.Second,
+			0,
+			0,
+			false,
+			genesisTime,
+			mockTimer,
+			nil,
+		)
+		mockTimer.IncrementSeconds(1)
+		infos[i].HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	}
+
+	mockTimer.IncrementSeconds(10)
+	heartbeat.BatchComputeActive(infos, mockTimer.Now(), 3)
+
+	for i := 0; i < numRecords; i++ {
+		assert.False(t, infos[i].GetIsActive())
+		assert.True(t, infos[i].GetTotalDownTime().Duration > 0)
+	}
+}
+
+func TestBatchComputeActive_ShouldNotExceedMaxConcurrency(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	numRecords := 50
+	infos := make([]*heartbeat.HeartbeatMessageInfoType, numRecords)
+	for i := 0; i < numRecords; i++ {
+		infos[i], _ = heartbeat.NewHeartbeatMessageInfo(
+			5*time.Second,
+			5*time.Second,
+			0,
+			0,
+			false,
+			mockTimer.Now(),
+			mockTimer,
+			nil,
+		)
+	}
+
+	maxConcurrency := int32(4)
+	numRunning := int32(0)
+	maxRunning := int32(0)
+	numProcessed := int32(0)
+	heartbeat.ProcessInBatch(infos, int(maxConcurrency), func(hbmi *heartbeat.HeartbeatMessageInfoType) {
+		running := atomic.AddInt32(&numRunning, 1)
+		for {
+			currentMax := atomic.LoadInt32(&maxRunning)
+			if running <= currentMax || atomic.CompareAndSwapInt32(&maxRunning, currentMax, running) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&numProcessed, 1)
+		atomic.AddInt32(&numRunning, -1)
+	})
+
+	assert.Equal(t, int32(numRecords), atomic.LoadInt32(&numProcessed))
+	assert.True(t, atomic.LoadInt32(&maxRunning) <= maxConcurrency)
+	assert.True(t, atomic.LoadInt32(&maxRunning) > 0)
+}
+
+//------- UptimeAt
+
+func TestHeartbeatMessageInfo_UptimeAtShouldMatchComputeActive(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	genesisTime := mockTimer.Now()
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		5*time.Second,
+		5*time.Second,
+		0,
+		0,
+		false,
+		genesisTime,
+		mockTimer,
+		nil,
+	)
+
+	mockTimer.IncrementSeconds(1)
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	mockTimer.IncrementSeconds(2)
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+
+	for _, secondsAhead := range []int{3, 10} {
+		mockTimer.IncrementSeconds(secondsAhead)
+		upBefore := hbmi.GetTotalUpTime().Duration
+		downBefore := hbmi.GetTotalDownTime().Duration
+
+		projectedUp, projectedDown := hbmi.UptimeAt(mockTimer.Now())
+		assert.Equal(t, upBefore, hbmi.GetTotalUpTime().Duration)
+		assert.Equal(t, downBefore, hbmi.GetTotalDownTime().Duration)
+
+		hbmi.ComputeActive(mockTimer.Now())
+		assert.Equal(t, hbmi.GetTotalUpTime().Duration, projectedUp)
+		assert.Equal(t, hbmi.GetTotalDownTime().Duration, projectedDown)
+	}
+}
+
+func TestHeartbeatMessageInfo_UptimeAtBeforeGenesisShouldReturnTotals(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	genesisTime := time.Unix(10, 0)
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		5*time.Second,
+		5*time.Second,
+		0,
+		0,
+		false,
+		genesisTime,
+		mockTimer,
+		nil,
+	)
+
+	up, down := hbmi.UptimeAt(time.Unix(5, 0))
+
+	assert.Equal(t, time.Duration(0), up)
+	assert.Equal(t, time.Duration(0), down)
+}
+
+//------- IsNonceStalled
+
+func TestHeartbeatMessageInfo_AdvancingNonceShouldNotBeStalled(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		mockTimer.IncrementSeconds(1)
+		hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", nonce, 0)
+		assert.False(t, hbmi.IsNonceStalled())
+		assert.Equal(t, nonce, hbmi.LastKnownNonce())
+	}
+}
+
+func TestHeartbeatMessageInfo_NonAdvancingNonceShouldBeStalled(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 5, 0)
+	assert.False(t, hbmi.IsNonceStalled())
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 5, 0)
+	assert.True(t, hbmi.IsNonceStalled())
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 4, 0)
+	assert.True(t, hbmi.IsNonceStalled())
+	assert.Equal(t, uint64(4), hbmi.LastKnownNonce())
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 6, 0)
+	assert.False(t, hbmi.IsNonceStalled())
+}
+
+func TestHeartbeatMessageInfo_MissingNonceShouldBeIgnored(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+
+	assert.False(t, hbmi.IsNonceStalled())
+	assert.Equal(t, uint64(0), hbmi.LastKnownNonce())
+}
+
+//------- AlertLevel
+
+func TestHeartbeatMessageInfo_SetAlertThresholdsInvalidValuesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		time.Second,
+		time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+
+	assert.Equal(t, heartbeat.ErrInvalidAlertThresholds, hbmi.SetAlertThresholds(0, 3))
+	assert.Equal(t, heartbeat.ErrInvalidAlertThresholds, hbmi.SetAlertThresholds(3, 2))
+	assert.Nil(t, hbmi.SetAlertThresholds(2, 2))
+}
+
+func TestHeartbeatMessageInfo_AlertLevelShouldEscalateAndReset(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		time.Second,
+		time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+	_ = hbmi.SetAlertThresholds(2, 4)
+
+	mockTimer.IncrementSeconds(1)
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	hbmi.ComputeActive(mockTimer.Now())
+	assert.Equal(t, heartbeat.AlertNone, hbmi.AlertLevel())
+
+	expectedLevels := []heartbeat.AlertLevel{
+		heartbeat.AlertNone,
+		heartbeat.AlertWarning,
+		heartbeat.AlertWarning,
+		heartbeat.AlertCritical,
+		heartbeat.AlertCritical,
+	}
+	for _, expectedLevel := range expectedLevels {
+		mockTimer.IncrementSeconds(2)
+		hbmi.ComputeActive(mockTimer.Now())
+		assert.Equal(t, expectedLevel, hbmi.AlertLevel())
+	}
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	assert.Equal(t, heartbeat.AlertNone, hbmi.AlertLevel())
+	hbmi.ComputeActive(mockTimer.Now())
+	assert.Equal(t, heartbeat.AlertNone, hbmi.AlertLevel())
+}
+
+func TestHeartbeatMessageInfo_AlertLevelIgnoredPeerShouldReturnNone(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		time.Second,
+		time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+	_ = hbmi.SetAlertThresholds(1, 2)
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	for i := 0; i < 3; i++ {
+		mockTimer.IncrementSeconds(2)
+		hbmi.ComputeActive(mockTimer.Now())
+	}
+	assert.Equal(t, heartbeat.AlertCritical, hbmi.AlertLevel())
+
+	hbmi.SetIgnored(true)
+	assert.Equal(t, heartbeat.AlertNone, hbmi.AlertLevel())
+
+	hbmi.SetIgnored(false)
+	assert.Equal(t, heartbeat.AlertCritical, hbmi.AlertLevel())
+}
+
+func TestAlertLevel_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "none", heartbeat.AlertNone.String())
+	assert.Equal(t, "warning", heartbeat.AlertWarning.String())
+	assert.Equal(t, "critical", heartbeat.AlertCritical.String())
+	assert.Equal(t, "unknown", heartbeat.AlertLevel(100).String())
+}
+
+//------- grace and settle periods
+
+func TestHeartbeatMessageInfo_OscillatingPeerWithinGracePeriodShouldStayActive(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		3*time.Second,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	assert.True(t, hbmi.GetIsActive())
+
+	for i := 0; i < 5; i++ {
+		mockTimer.IncrementSeconds(12)
+		hbmi.ComputeActive(mockTimer.Now())
+		assert.True(t, hbmi.GetIsActive())
+
+		hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+		assert.True(t, hbmi.GetIsActive())
+	}
+
+	mockTimer.IncrementSeconds(14)
+	hbmi.ComputeActive(mockTimer.Now())
+	assert.False(t, hbmi.GetIsActive())
+}
+
+func TestHeartbeatMessageInfo_OscillatingPeerShouldNotBecomeActiveBeforeSettlePeriod(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		5*time.Second,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	assert.False(t, hbmi.GetIsActive())
+
+	for i := 0; i < 3; i++ {
+		mockTimer.IncrementSeconds(3)
+		hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	}
+	assert.True(t, hbmi.GetIsActive())
+
+	for i := 0; i < 3; i++ {
+		mockTimer.IncrementSeconds(11)
+		hbmi.ComputeActive(mockTimer.Now())
+		assert.False(t, hbmi.GetIsActive())
+
+		hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+		assert.False(t, hbmi.GetIsActive())
+	}
+
+	mockTimer.IncrementSeconds(5)
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	assert.True(t, hbmi.GetIsActive())
+}
+
+func TestHeartbeatMessageInfo_ComputeActiveShouldActivatePeerAfterSettlePeriod(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		5*time.Second,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	mockTimer.IncrementSeconds(4)
+	hbmi.ComputeActive(mockTimer.Now())
+	assert.False(t, hbmi.GetIsActive())
+
+	mockTimer.IncrementSeconds(1)
+	hbmi.ComputeActive(mockTimer.Now())
+	assert.True(t, hbmi.GetIsActive())
+}
+
+func TestHeartbeatMessageInfo_SettlingPeerShouldNotRaiseAlerts(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		time.Hour,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+	_ = hbmi.SetAlertThresholds(1, 2)
+
+	for i := 0; i < 5; i++ {
+		hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+		mockTimer.IncrementSeconds(5)
+		hbmi.ComputeActive(mockTimer.Now())
+		assert.False(t, hbmi.GetIsActive())
+		assert.Equal(t, heartbeat.AlertNone, hbmi.AlertLevel())
+	}
+
+	mockTimer.IncrementSeconds(10)
+	hbmi.ComputeActive(mockTimer.Now())
+	assert.Equal(t, heartbeat.AlertWarning, hbmi.AlertLevel())
+}
+
+//------- RestartCount
+
+func TestHeartbeatMessageInfo_DifferentBootTimestampShouldIncrementRestartCountOnce(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 100)
+	assert.Equal(t, uint32(0), hbmi.RestartCount())
+
+	mockTimer.IncrementSeconds(1)
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 200)
+	assert.Equal(t, uint32(1), hbmi.RestartCount())
+
+	mockTimer.IncrementSeconds(1)
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 200)
+	assert.Equal(t, uint32(1), hbmi.RestartCount())
+}
+
+func TestHeartbeatMessageInfo_MissingBootTimestampShouldNotCountAsRestart(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 100)
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 100)
+
+	assert.Equal(t, uint32(0), hbmi.RestartCount())
+}
+
+//------- GetUptimePercentageWindow
+
+func TestHeartbeatMessageInfo_SetUptimeWindowInvalidValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		time.Time{},
+		&mock.MockTimer{},
+		nil,
+	)
+
+	err := hbmi.SetUptimeWindow(0)
+
+	assert.Equal(t, heartbeat.ErrInvalidUptimeWindow, err)
+}
+
+func TestHeartbeatMessageInfo_GetUptimePercentageWindowShouldAgeOutOldDowntime(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+	_ = hbmi.SetUptimeWindow(60 * time.Second)
+
+	mockTimer.IncrementSeconds(30)
+	hbmi.ComputeActive(mockTimer.Now())
+	assert.Equal(t, float64(0), hbmi.GetUptimePercentageWindow())
+
+	for i := 0; i < 6; i++ {
+		hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+		mockTimer.IncrementSeconds(5)
+	}
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	assert.Equal(t, float64(50), hbmi.GetUptimePercentageWindow())
+
+	for i := 0; i < 6; i++ {
+		mockTimer.IncrementSeconds(5)
+		hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	}
+	assert.Equal(t, float64(100), hbmi.GetUptimePercentageWindow())
+
+	up, down := hbmi.UptimeAt(mockTimer.Now())
+	assert.Equal(t, 60*time.Second, up)
+	assert.Equal(t, 30*time.Second, down)
+}
+
+func TestHeartbeatMessageInfo_GetUptimePercentageWindowShouldIncludeNotYetComputedTime(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+	_ = hbmi.SetUptimeWindow(40 * time.Second)
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	mockTimer.IncrementSeconds(10)
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+
+	mockTimer.IncrementSeconds(30)
+	assert.Equal(t, float64(25), hbmi.GetUptimePercentageWindow())
+}
+
+func TestHeartbeatMessageInfo_GetUptimePercentageWindowOscillatingPeerShouldWork(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		time.Second,
+		time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+	_ = hbmi.SetUptimeWindow(time.Hour)
+
+	numCycles := 100
+	for i := 0; i < numCycles; i++ {
+		hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+		mockTimer.IncrementSeconds(1)
+		hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+		mockTimer.IncrementSeconds(3)
+		hbmi.ComputeActive(mockTimer.Now())
+	}
+
+	assert.Equal(t, float64(25), hbmi.GetUptimePercentageWindow())
+}
+
+//------- MarshalJSON
+
+func TestHeartbeatMessageInfo_MarshalJSONShouldRenderDurationsAsSeconds(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		100*time.Second,
+		100*time.Second,
+		0,
+		0,
+		true,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+
+	hbmi.HeartbeatReceived(uint32(1), uint32(2), "v0.1", "node", 0, 0)
+	mockTimer.IncrementSeconds(10)
+	hbmi.HeartbeatReceived(uint32(1), uint32(2), "v0.2", "node", 0, 0)
+	mockTimer.IncrementSeconds(25)
+	hbmi.ComputeActive(mockTimer.Now())
+
+	buff, err := json.Marshal(hbmi)
+	assert.Nil(t, err)
+
+	fields := make(map[string]interface{})
+	err = json.Unmarshal(buff, &fields)
+	assert.Nil(t, err)
+
+	expectedKeys := []string{
+		"timeStamp",
+		"maxInactiveTimeSec",
+		"totalUpTimeSec",
+		"totalDownTimeSec",
+		"isActive",
+		"receivedShardID",
+		"computedShardID",
+		"versionNumber",
+		"nodeDisplayName",
+		"isValidator",
+		"lastUptimeDowntime",
+		"genesisTime",
+		"restartCount",
+		"previousVersion",
+		"versionChangedAt",
+	}
+	assert.Equal(t, len(expectedKeys), len(fields))
+	for _, key := range expectedKeys {
+		_, ok := fields[key]
+		assert.True(t, ok, key)
+	}
+
+	assert.Equal(t, float64(35), fields["totalUpTimeSec"])
+	assert.Equal(t, float64(0), fields["totalDownTimeSec"])
+	assert.Equal(t, float64(25), fields["maxInactiveTimeSec"])
+	assert.Equal(t, float64(1), fields["computedShardID"])
+	assert.Equal(t, float64(2), fields["receivedShardID"])
+	assert.Equal(t, "v0.2", fields["versionNumber"])
+	assert.Equal(t, "v0.1", fields["previousVersion"])
+	assert.Equal(t, true, fields["isActive"])
+	assert.Equal(t, true, fields["isValidator"])
+}
+
+//------- version changes
+
+func TestHeartbeatMessageInfo_VersionChangeShouldRecordPreviousVersionAndTime(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	assert.Equal(t, "", hbmi.PreviousVersion())
+	assert.True(t, hbmi.VersionChangedAt().IsZero())
+
+	mockTimer.IncrementSeconds(5)
+	changeTime := mockTimer.Now()
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.2", "undefined", 0, 0)
+	assert.Equal(t, "v0.1", hbmi.PreviousVersion())
+	assert.Equal(t, changeTime, hbmi.VersionChangedAt())
+
+	mockTimer.IncrementSeconds(5)
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.2", "undefined", 0, 0)
+	assert.Equal(t, "v0.1", hbmi.PreviousVersion())
+	assert.Equal(t, changeTime, hbmi.VersionChangedAt())
+	assert.Equal(t, "v0.2", hbmi.GetVersionNumber())
+}
+
+//------- AverageArrivalInterval
+
+func TestHeartbeatMessageInfo_AverageArrivalIntervalFirstHeartbeatShouldBeIgnored(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	mockTimer.IncrementSeconds(100)
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		time.Time{},
+		mockTimer,
+		nil,
+	)
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+
+	assert.Equal(t, time.Duration(0), hbmi.AverageArrivalInterval())
+}
+
+func TestHeartbeatMessageInfo_AverageArrivalIntervalRegularArrivalsShouldMatchInterval(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+
+	for i := 0; i < 10; i++ {
+		mockTimer.IncrementSeconds(5)
+		hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	}
+
+	assert.Equal(t, 5*time.Second, hbmi.AverageArrivalInterval())
+}
+
+func TestHeartbeatMessageInfo_AverageArrivalIntervalIrregularArrivalsShouldTrend(t *testing.T) {
+	t.Parallel()
+
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+
+	for i := 0; i < 5; i++ {
+		mockTimer.IncrementSeconds(5)
+		hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	}
+	assert.Equal(t, 5*time.Second, hbmi.AverageArrivalInterval())
+
+	previousAvg := hbmi.AverageArrivalInterval()
+	for i := 0; i < 5; i++ {
+		mockTimer.IncrementSeconds(20)
+		hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+
+		avg := hbmi.AverageArrivalInterval()
+		assert.True(t, avg > previousAvg)
+		assert.True(t, avg < 20*time.Second)
+		previousAvg = avg
+	}
+
+	for i := 0; i < 5; i++ {
+		mockTimer.IncrementSeconds(5)
+		hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+
+		avg := hbmi.AverageArrivalInterval()
+		assert.True(t, avg < previousAvg)
+		assert.True(t, avg > 5*time.Second)
+		previousAvg = avg
+	}
+}
+
+//------- MarshalForStorage / LoadFromStorage
+
+func TestHeartbeatMessageInfo_MarshalForStorageNilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		time.Time{},
+		&mock.MockTimer{},
+		nil,
+	)
+
+	buff, err := hbmi.MarshalForStorage(nil)
+
+	assert.Nil(t, buff)
+	assert.Equal(t, heartbeat.ErrNilMarshalizer, err)
+}
+
+func TestHeartbeatMessageInfo_MarshalForStorageLoadFromStorageShouldRestoreState(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerFake{}
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+
+	hbmi.HeartbeatReceived(uint32(1), uint32(2), "v0.1", "node", 0, 0)
+	mockTimer.IncrementSeconds(5)
+	hbmi.HeartbeatReceived(uint32(1), uint32(2), "v0.1", "node", 0, 0)
+	mockTimer.IncrementSeconds(30)
+	hbmi.ComputeActive(mockTimer.Now())
+
+	buff, err := hbmi.MarshalForStorage(marshalizer)
+	assert.Nil(t, err)
+
+	restartedTimer := &mock.MockTimer{}
+	restartedTimer.SetSeconds(1000)
+	restoredHbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		time.Time{},
+		restartedTimer,
+		nil,
+	)
+	err = restoredHbmi.LoadFromStorage(marshalizer, buff)
+	assert.Nil(t, err)
+
+	assert.Equal(t, hbmi.GetTotalUpTime(), restoredHbmi.GetTotalUpTime())
+	assert.Equal(t, hbmi.GetTotalDownTime(), restoredHbmi.GetTotalDownTime())
+	assert.Equal(t, hbmi.GetMaxInactiveTime(), restoredHbmi.GetMaxInactiveTime())
+	assert.Equal(t, hbmi.GetTimeStamp().Unix(), restoredHbmi.GetTimeStamp().Unix())
+	assert.Equal(t, uint32(2), restoredHbmi.GetReceiverShardId())
+	assert.False(t, restoredHbmi.GetIsActive())
+
+	restartedTimer.IncrementSeconds(10)
+	restoredHbmi.ComputeActive(restartedTimer.Now())
+	assert.Equal(t, hbmi.GetTotalDownTime().Duration+10*time.Second, restoredHbmi.GetTotalDownTime().Duration)
+}
+
+func TestHeartbeatMessageInfo_LoadFromStorageShouldUseValidatorThreshold(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerFake{}
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		5*time.Second,
+		0,
+		0,
+		true,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "node", 0, 0)
+
+	buff, _ := hbmi.MarshalForStorage(marshalizer)
+
+	restartedTimer := &mock.MockTimer{}
+	restartedTimer.SetSeconds(7)
+	restoredHbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		5*time.Second,
+		0,
+		0,
+		false,
+		time.Time{},
+		restartedTimer,
+		nil,
+	)
+	err := restoredHbmi.LoadFromStorage(marshalizer, buff)
+
+	assert.Nil(t, err)
+	assert.False(t, restoredHbmi.GetIsActive())
+}
+
+func TestHeartbeatMessageInfo_LoadFromStorageActivePeerShouldStayActiveDuringSettlePeriod(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerFake{}
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		nil,
+	)
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "node", 0, 0)
+
+	buff, _ := hbmi.MarshalForStorage(marshalizer)
+
+	transitions := make([]bool, 0)
+	restartedTimer := &mock.MockTimer{}
+	restartedTimer.SetSeconds(5)
+	restoredHbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		30*time.Second,
+		false,
+		time.Time{},
+		restartedTimer,
+		func(isActive bool) {
+			transitions = append(transitions, isActive)
+		},
+	)
+	_ = restoredHbmi.LoadFromStorage(marshalizer, buff)
+	assert.True(t, restoredHbmi.GetIsActive())
+
+	restartedTimer.IncrementSeconds(1)
+	restoredHbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "node", 0, 0)
+
+	assert.True(t, restoredHbmi.GetIsActive())
+	assert.Equal(t, 0, len(transitions))
+}
+
+//------- onStatusChange
+
+func TestHeartbeatMessageInfo_StatusChangeHandlerShouldBeCalledOnlyOnTransitions(t *testing.T) {
+	t.Parallel()
+
+	transitions := make([]bool, 0)
+	mockTimer := &mock.MockTimer{}
+	hbmi, _ := heartbeat.NewHeartbeatMessageInfo(
+		10*time.Second,
+		10*time.Second,
+		0,
+		0,
+		false,
+		mockTimer.Now(),
+		mockTimer,
+		func(isActive bool) {
+			transitions = append(transitions, isActive)
+		},
+	)
+
+	mockTimer.IncrementSeconds(1)
+	hbmi.ComputeActive(mockTimer.Now())
+	assert.Equal(t, 0, len(transitions))
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	mockTimer.IncrementSeconds(5)
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	mockTimer.IncrementSeconds(5)
+	hbmi.ComputeActive(mockTimer.Now())
+	assert.Equal(t, []bool{true}, transitions)
+
+	mockTimer.IncrementSeconds(20)
+	hbmi.ComputeActive(mockTimer.Now())
+	mockTimer.IncrementSeconds(20)
+	hbmi.ComputeActive(mockTimer.Now())
+	assert.Equal(t, []bool{true, false}, transitions)
+
+	hbmi.HeartbeatReceived(uint32(0), uint32(0), "v0.1", "undefined", 0, 0)
+	hbmi.ComputeActive(mockTimer.Now())
+	assert.Equal(t, []bool{true, false, true}, transitions)
+}
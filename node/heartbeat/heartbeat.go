@@ -12,6 +12,9 @@ type Heartbeat struct {
 	ShardID         uint32
 	VersionNumber   string
 	NodeDisplayName string
+	Nonce           uint64
+	BootTimestamp   int64
+	Timestamp       int64
 }
 
 // PubKeyHeartbeat returns the heartbeat status for a public key
@@ -27,6 +30,27 @@ type PubKeyHeartbeat struct {
 	VersionNumber   string    `json:"versionNumber"`
 	IsValidator     bool      `json:"isValidator"`
 	NodeDisplayName string    `json:"nodeDisplayName"`
+	RestartCount    uint32    `json:"restartCount"`
+}
+
+// HeartbeatExportDTO is the stable form in which the state kept for a peer is exported to external
+// monitoring tools. All durations are expressed in seconds
+type HeartbeatExportDTO struct {
+	TimeStamp          time.Time `json:"timeStamp"`
+	MaxInactiveTime    int       `json:"maxInactiveTimeSec"`
+	TotalUpTime        int       `json:"totalUpTimeSec"`
+	TotalDownTime      int       `json:"totalDownTimeSec"`
+	IsActive           bool      `json:"isActive"`
+	ReceivedShardID    uint32    `json:"receivedShardID"`
+	ComputedShardID    uint32    `json:"computedShardID"`
+	VersionNumber      string    `json:"versionNumber"`
+	NodeDisplayName    string    `json:"nodeDisplayName"`
+	IsValidator        bool      `json:"isValidator"`
+	LastUptimeDowntime time.Time `json:"lastUptimeDowntime"`
+	GenesisTime        time.Time `json:"genesisTime"`
+	RestartCount       uint32    `json:"restartCount"`
+	PreviousVersion    string    `json:"previousVersion"`
+	VersionChangedAt   time.Time `json:"versionChangedAt"`
 }
 
 // HeartbeatDTO is the struct used for handling DB operations for heartbeatMessageInfo struct
@@ -44,4 +68,8 @@ type HeartbeatDTO struct {
 	IsValidator                 bool
 	LastUptimeDowntime          time.Time
 	GenesisTime                 time.Time
+	BootTimestamp               int64
+	RestartCount                uint32
+	PreviousVersion             string
+	VersionChangedAt            time.Time
 }
@@ -18,27 +18,43 @@ import (
 
 var log = logger.DefaultLogger()
 
+// defaultMaxAllowedTimeSkew is how far ahead of the local clock the timestamp of a received heartbeat may be
+// before the sender's clock is reported as skewed
+const defaultMaxAllowedTimeSkew = 30 * time.Second
+
 // Monitor represents the heartbeat component that processes received heartbeat messages
 type Monitor struct {
-	maxDurationPeerUnresponsive time.Duration
-	marshalizer                 marshal.Marshalizer
-	mutHeartbeatMessages        sync.RWMutex
-	heartbeatMessages           map[string]*heartbeatMessageInfo
-	mutPubKeysMap               sync.RWMutex
-	pubKeysMap                  map[uint32][]string
-	mutFullPeersSlice           sync.RWMutex
-	fullPeersSlice              [][]byte
-	appStatusHandler            core.AppStatusHandler
-	genesisTime                 time.Time
-	messageHandler              MessageHandler
-	storer                      HeartbeatStorageHandler
-	timer                       Timer
+	maxDurationPeerUnresponsive      time.Duration
+	maxDurationValidatorUnresponsive time.Duration
+	gracePeriod                      time.Duration
+	settlePeriod                     time.Duration
+	marshalizer                      marshal.Marshalizer
+	mutHeartbeatMessages             sync.RWMutex
+	heartbeatMessages                map[string]*heartbeatMessageInfo
+	mutPubKeysMap                    sync.RWMutex
+	pubKeysMap                       map[uint32][]string
+	mutFullPeersSlice                sync.RWMutex
+	fullPeersSlice                   [][]byte
+	appStatusHandler                 core.AppStatusHandler
+	genesisTime                      time.Time
+	messageHandler                   MessageHandler
+	storer                           HeartbeatStorageHandler
+	timer                            Timer
+	mutStatusChangeHandler           sync.RWMutex
+	statusChangeHandler              func(pk string, isActive bool)
+	mutMaxAllowedTimeSkew            sync.RWMutex
+	maxAllowedTimeSkew               time.Duration
 }
 
-// NewMonitor returns a new monitor instance
+// NewMonitor returns a new monitor instance. The maxDurationValidatorUnresponsive is applied to the validators
+// instead of maxDurationPeerUnresponsive and can not be greater than it. The grace and settle periods delay
+// the transitions to inactive and back to active
 func NewMonitor(
 	marshalizer marshal.Marshalizer,
 	maxDurationPeerUnresponsive time.Duration,
+	maxDurationValidatorUnresponsive time.Duration,
+	gracePeriod time.Duration,
+	settlePeriod time.Duration,
 	pubKeysMap map[uint32][]string,
 	genesisTime time.Time,
 	messageHandler MessageHandler,
@@ -61,16 +77,29 @@ func NewMonitor(
 	if timer == nil || timer.IsInterfaceNil() {
 		return nil, ErrNilTimer
 	}
+	if maxDurationPeerUnresponsive == 0 {
+		return nil, ErrInvalidMaxDurationPeerUnresponsive
+	}
+	if maxDurationValidatorUnresponsive == 0 || maxDurationValidatorUnresponsive > maxDurationPeerUnresponsive {
+		return nil, ErrInvalidMaxDurationValidatorUnresponsive
+	}
+	if gracePeriod < 0 || settlePeriod < 0 {
+		return nil, ErrInvalidHysteresisDuration
+	}
 
 	mon := &Monitor{
-		marshalizer:                 marshalizer,
-		heartbeatMessages:           make(map[string]*heartbeatMessageInfo),
-		maxDurationPeerUnresponsive: maxDurationPeerUnresponsive,
-		appStatusHandler:            &statusHandler.NilStatusHandler{},
-		genesisTime:                 genesisTime,
-		messageHandler:              messageHandler,
-		storer:                      storer,
-		timer:                       timer,
+		marshalizer:                      marshalizer,
+		heartbeatMessages:                make(map[string]*heartbeatMessageInfo),
+		maxDurationPeerUnresponsive:      maxDurationPeerUnresponsive,
+		maxDurationValidatorUnresponsive: maxDurationValidatorUnresponsive,
+		gracePeriod:                      gracePeriod,
+		settlePeriod:                     settlePeriod,
+		appStatusHandler:                 &statusHandler.NilStatusHandler{},
+		genesisTime:                      genesisTime,
+		messageHandler:                   messageHandler,
+		storer:                           storer,
+		timer:                            timer,
+		maxAllowedTimeSkew:               defaultMaxAllowedTimeSkew,
 	}
 
 	err := mon.storer.UpdateGenesisTime(genesisTime)
@@ -97,7 +126,16 @@ func (m *Monitor) initializeHeartbeatMessagesInfo(pubKeysMap map[uint32][]string
 		for _, pubkey := range pubKeys {
 			err := m.loadHbmiFromStorer(pubkey)
 			if err != nil { // if pubKey not found in DB, create a new instance
-				mhbi, errNewHbmi := newHeartbeatMessageInfo(m.maxDurationPeerUnresponsive, true, m.genesisTime, m.timer)
+				mhbi, errNewHbmi := newHeartbeatMessageInfo(
+					m.maxDurationPeerUnresponsive,
+					m.maxDurationValidatorUnresponsive,
+					m.gracePeriod,
+					m.settlePeriod,
+					true,
+					m.genesisTime,
+					m.timer,
+					m.statusChangeNotifier(pubkey),
+				)
 				if errNewHbmi != nil {
 					return errNewHbmi
 				}
@@ -139,13 +177,22 @@ func (m *Monitor) loadHbmiFromStorer(pubKey string) error {
 		return err
 	}
 
-	receivedHbmi := m.convertFromExportedStruct(*hbmiDTO, m.maxDurationPeerUnresponsive)
-	receivedHbmi.getTimeHandler = m.timer.Now
-	receivedHbmi.isActive = m.timer.Now().Sub(receivedHbmi.lastUptimeDowntime) <= m.maxDurationPeerUnresponsive
-	receivedHbmi.lastUptimeDowntime = m.timer.Now()
-	receivedHbmi.genesisTime = m.genesisTime
+	receivedHbmi, err := newHeartbeatMessageInfo(
+		m.maxDurationPeerUnresponsive,
+		m.maxDurationValidatorUnresponsive,
+		m.gracePeriod,
+		m.settlePeriod,
+		hbmiDTO.IsValidator,
+		m.genesisTime,
+		m.timer,
+		m.statusChangeNotifier(pubKey),
+	)
+	if err != nil {
+		return err
+	}
+	receivedHbmi.loadStorageDTO(*hbmiDTO)
 
-	m.heartbeatMessages[pubKey] = &receivedHbmi
+	m.heartbeatMessages[pubKey] = receivedHbmi
 
 	return nil
 }
@@ -160,6 +207,60 @@ func (m *Monitor) SetAppStatusHandler(ash core.AppStatusHandler) error {
 	return nil
 }
 
+// SetStatusChangeHandler sets the handler called each time a peer transitions between active and inactive.
+// The public key is provided hex encoded and the handler should not call back into the monitor
+func (m *Monitor) SetStatusChangeHandler(handler func(pk string, isActive bool)) error {
+	if handler == nil {
+		return ErrNilStatusChangeHandler
+	}
+
+	m.mutStatusChangeHandler.Lock()
+	m.statusChangeHandler = handler
+	m.mutStatusChangeHandler.Unlock()
+
+	return nil
+}
+
+func (m *Monitor) statusChangeNotifier(pubKey string) func(isActive bool) {
+	return func(isActive bool) {
+		m.mutStatusChangeHandler.RLock()
+		handler := m.statusChangeHandler
+		m.mutStatusChangeHandler.RUnlock()
+
+		if handler == nil {
+			return
+		}
+
+		handler(hex.EncodeToString([]byte(pubKey)), isActive)
+	}
+}
+
+// SetMaxAllowedTimeSkew sets how far ahead of the local clock the timestamp of a received heartbeat may be
+// before the sender's clock is reported as skewed
+func (m *Monitor) SetMaxAllowedTimeSkew(maxAllowedTimeSkew time.Duration) error {
+	if maxAllowedTimeSkew <= 0 {
+		return ErrInvalidMaxAllowedTimeSkew
+	}
+
+	m.mutMaxAllowedTimeSkew.Lock()
+	m.maxAllowedTimeSkew = maxAllowedTimeSkew
+	m.mutMaxAllowedTimeSkew.Unlock()
+
+	return nil
+}
+
+func (m *Monitor) isTooFarInFuture(timestamp int64) bool {
+	if timestamp == 0 {
+		return false
+	}
+
+	m.mutMaxAllowedTimeSkew.RLock()
+	maxAllowedTimeSkew := m.maxAllowedTimeSkew
+	m.mutMaxAllowedTimeSkew.RUnlock()
+
+	return time.Unix(timestamp, 0).Sub(m.timer.Now()) > maxAllowedTimeSkew
+}
+
 // ProcessReceivedMessage satisfies the p2p.MessageProcessor interface so it can be called
 // by the p2p subsystem each time a new heartbeat message arrives
 func (m *Monitor) ProcessReceivedMessage(message p2p.MessageP2P, _ func(buffToSend []byte)) error {
@@ -168,6 +269,12 @@ func (m *Monitor) ProcessReceivedMessage(message p2p.MessageP2P, _ func(buffToSe
 		return err
 	}
 
+	//the timestamp is informative only, the heartbeat is processed using the local time
+	if m.isTooFarInFuture(hbRecv.Timestamp) {
+		log.Warn(fmt.Sprintf("heartbeat: message from %s has the timestamp %d, too far ahead of the local time, "+
+			"the sender's clock might be skewed", hex.EncodeToString(hbRecv.Pubkey), hbRecv.Timestamp))
+	}
+
 	//message is validated, process should be done async, method can return nil
 	go m.addHeartbeatMessageToMap(hbRecv)
 
@@ -182,7 +289,16 @@ func (m *Monitor) addHeartbeatMessageToMap(hb *Heartbeat) {
 	hbmi, ok := m.heartbeatMessages[pubKeyStr]
 	if hbmi == nil || !ok {
 		var err error
-		hbmi, err = newHeartbeatMessageInfo(m.maxDurationPeerUnresponsive, false, m.genesisTime, m.timer)
+		hbmi, err = newHeartbeatMessageInfo(
+			m.maxDurationPeerUnresponsive,
+			m.maxDurationValidatorUnresponsive,
+			m.gracePeriod,
+			m.settlePeriod,
+			false,
+			m.genesisTime,
+			m.timer,
+			m.statusChangeNotifier(pubKeyStr),
+		)
 		if err != nil {
 			log.Error(err.Error())
 			m.mutHeartbeatMessages.Unlock()
@@ -195,8 +311,8 @@ func (m *Monitor) addHeartbeatMessageToMap(hb *Heartbeat) {
 	computedShardID := m.computeShardID(pubKeyStr)
 
 	hbmi.updateMutex.Lock()
-	hbmi.HeartbeatReceived(computedShardID, hb.ShardID, hb.VersionNumber, hb.NodeDisplayName)
-	hbDTO := m.convertToExportedStruct(hbmi)
+	hbmi.HeartbeatReceived(computedShardID, hb.ShardID, hb.VersionNumber, hb.NodeDisplayName, hb.Nonce, hb.BootTimestamp)
+	hbDTO := hbmi.toStorageDTO()
 	hbmi.updateMutex.Unlock()
 
 	err := m.storer.SavePubkeyData(hb.Pubkey, &hbDTO)
@@ -251,6 +367,9 @@ func (m *Monitor) computeAllHeartbeatMessages() {
 	counterConnectedNodes := 0
 	for _, v := range m.heartbeatMessages {
 		v.computeActive(m.timer.Now())
+		if v.IsIgnored() {
+			continue
+		}
 		if v.isActive {
 			counterConnectedNodes++
 
@@ -265,6 +384,42 @@ func (m *Monitor) computeAllHeartbeatMessages() {
 	m.appStatusHandler.SetUInt64Value(core.MetricConnectedNodes, uint64(counterConnectedNodes))
 }
 
+// SaveHeartbeatsToStorage persists the up to date state of all known peers so that the uptime accounting
+// can be restored after a restart
+func (m *Monitor) SaveHeartbeatsToStorage() error {
+	m.computeAllHeartbeatMessages()
+
+	m.mutHeartbeatMessages.RLock()
+	defer m.mutHeartbeatMessages.RUnlock()
+
+	for pubKey, hbmi := range m.heartbeatMessages {
+		hbmi.updateMutex.Lock()
+		hbDTO := hbmi.toStorageDTO()
+		hbmi.updateMutex.Unlock()
+
+		err := m.storer.SavePubkeyData([]byte(pubKey), &hbDTO)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetPeerIgnored marks the peer identified by the provided public key as ignored (or not). Ignored peers
+// are still tracked but are excluded from the live validators and connected nodes metrics
+func (m *Monitor) SetPeerIgnored(pubKey []byte, ignored bool) error {
+	m.mutHeartbeatMessages.RLock()
+	hbmi, ok := m.heartbeatMessages[string(pubKey)]
+	m.mutHeartbeatMessages.RUnlock()
+	if !ok {
+		return ErrPeerNotFound
+	}
+
+	hbmi.SetIgnored(ignored)
+	return nil
+}
+
 // GetHeartbeats returns the heartbeat status
 func (m *Monitor) GetHeartbeats() []PubKeyHeartbeat {
 	m.computeAllHeartbeatMessages()
@@ -285,6 +440,7 @@ func (m *Monitor) GetHeartbeats() []PubKeyHeartbeat {
 			VersionNumber:   v.versionNumber,
 			IsValidator:     v.isValidator,
 			NodeDisplayName: v.nodeDisplayName,
+			RestartCount:    v.restartCount,
 		}
 		idx++
 	}
@@ -304,40 +460,3 @@ func (m *Monitor) IsInterfaceNil() bool {
 	}
 	return false
 }
-
-func (m *Monitor) convertToExportedStruct(v *heartbeatMessageInfo) HeartbeatDTO {
-	return HeartbeatDTO{
-		TimeStamp:          v.timeStamp,
-		MaxInactiveTime:    v.maxInactiveTime,
-		IsActive:           v.isActive,
-		ReceivedShardID:    v.receivedShardID,
-		ComputedShardID:    v.computedShardID,
-		TotalUpTime:        v.totalUpTime,
-		TotalDownTime:      v.totalDownTime,
-		VersionNumber:      v.versionNumber,
-		IsValidator:        v.isValidator,
-		NodeDisplayName:    v.nodeDisplayName,
-		LastUptimeDowntime: v.lastUptimeDowntime,
-		GenesisTime:        v.genesisTime,
-	}
-}
-
-func (m *Monitor) convertFromExportedStruct(hbDTO HeartbeatDTO, maxDuration time.Duration) heartbeatMessageInfo {
-	hbmi := heartbeatMessageInfo{
-		maxDurationPeerUnresponsive: maxDuration,
-		maxInactiveTime:             hbDTO.MaxInactiveTime,
-		timeStamp:                   hbDTO.TimeStamp,
-		isActive:                    hbDTO.IsActive,
-		totalUpTime:                 hbDTO.TotalUpTime,
-		totalDownTime:               hbDTO.TotalDownTime,
-		receivedShardID:             hbDTO.ReceivedShardID,
-		computedShardID:             hbDTO.ComputedShardID,
-		versionNumber:               hbDTO.VersionNumber,
-		nodeDisplayName:             hbDTO.NodeDisplayName,
-		isValidator:                 hbDTO.IsValidator,
-		lastUptimeDowntime:          hbDTO.LastUptimeDowntime,
-		genesisTime:                 hbDTO.GenesisTime,
-	}
-
-	return hbmi
-}
@@ -4,9 +4,11 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/ElrondNetwork/elrond-go/core"
 	"github.com/ElrondNetwork/elrond-go/node/heartbeat"
 	"github.com/ElrondNetwork/elrond-go/node/heartbeat/storage"
 	"github.com/ElrondNetwork/elrond-go/node/mock"
@@ -23,6 +25,9 @@ func TestNewMonitor_NilMarshalizerShouldErr(t *testing.T) {
 	mon, err := heartbeat.NewMonitor(
 		nil,
 		0,
+		0,
+		0,
+		0,
 		map[uint32][]string{0: {""}},
 		time.Now(),
 		&mock.MessageHandlerStub{},
@@ -41,6 +46,9 @@ func TestNewMonitor_EmptyPublicKeyListShouldErr(t *testing.T) {
 	mon, err := heartbeat.NewMonitor(
 		&mock.MarshalizerMock{},
 		0,
+		0,
+		0,
+		0,
 		make(map[uint32][]string),
 		time.Now(),
 		&mock.MessageHandlerStub{},
@@ -59,6 +67,9 @@ func TestNewMonitor_NilMessageHandlerShouldErr(t *testing.T) {
 	mon, err := heartbeat.NewMonitor(
 		&mock.MarshalizerMock{},
 		0,
+		0,
+		0,
+		0,
 		map[uint32][]string{0: {""}},
 		time.Now(),
 		nil,
@@ -77,6 +88,9 @@ func TestNewMonitor_NilHeartbeatStorerShouldErr(t *testing.T) {
 	mon, err := heartbeat.NewMonitor(
 		&mock.MarshalizerMock{},
 		0,
+		0,
+		0,
+		0,
 		map[uint32][]string{0: {""}},
 		time.Now(),
 		&mock.MessageHandlerStub{},
@@ -94,6 +108,9 @@ func TestNewMonitor_NilTimeHandlerShouldErr(t *testing.T) {
 	mon, err := heartbeat.NewMonitor(
 		&mock.MarshalizerMock{},
 		0,
+		0,
+		0,
+		0,
 		map[uint32][]string{0: {""}},
 		time.Now(),
 		&mock.MessageHandlerStub{},
@@ -105,6 +122,90 @@ func TestNewMonitor_NilTimeHandlerShouldErr(t *testing.T) {
 	assert.Equal(t, heartbeat.ErrNilTimer, err)
 }
 
+func TestNewMonitor_InvalidMaxDurationPeerUnresponsiveShouldErr(t *testing.T) {
+	t.Parallel()
+
+	th := &mock.MockTimer{}
+	mon, err := heartbeat.NewMonitor(
+		&mock.MarshalizerMock{},
+		0,
+		0,
+		0,
+		0,
+		map[uint32][]string{0: {""}},
+		time.Now(),
+		&mock.MessageHandlerStub{},
+		&mock.HeartbeatStorerStub{},
+		th,
+	)
+
+	assert.Nil(t, mon)
+	assert.Equal(t, heartbeat.ErrInvalidMaxDurationPeerUnresponsive, err)
+}
+
+func TestNewMonitor_ValidatorDurationGreaterThanPeerDurationShouldErr(t *testing.T) {
+	t.Parallel()
+
+	th := &mock.MockTimer{}
+	mon, err := heartbeat.NewMonitor(
+		&mock.MarshalizerMock{},
+		time.Second,
+		time.Second*2,
+		0,
+		0,
+		map[uint32][]string{0: {""}},
+		time.Now(),
+		&mock.MessageHandlerStub{},
+		&mock.HeartbeatStorerStub{},
+		th,
+	)
+
+	assert.Nil(t, mon)
+	assert.Equal(t, heartbeat.ErrInvalidMaxDurationValidatorUnresponsive, err)
+}
+
+func TestNewMonitor_NegativeGracePeriodShouldErr(t *testing.T) {
+	t.Parallel()
+
+	th := &mock.MockTimer{}
+	mon, err := heartbeat.NewMonitor(
+		&mock.MarshalizerMock{},
+		time.Second,
+		time.Second,
+		-time.Second,
+		0,
+		map[uint32][]string{0: {""}},
+		time.Now(),
+		&mock.MessageHandlerStub{},
+		&mock.HeartbeatStorerStub{},
+		th,
+	)
+
+	assert.Nil(t, mon)
+	assert.Equal(t, heartbeat.ErrInvalidHysteresisDuration, err)
+}
+
+func TestNewMonitor_NegativeSettlePeriodShouldErr(t *testing.T) {
+	t.Parallel()
+
+	th := &mock.MockTimer{}
+	mon, err := heartbeat.NewMonitor(
+		&mock.MarshalizerMock{},
+		time.Second,
+		time.Second,
+		0,
+		-time.Second,
+		map[uint32][]string{0: {""}},
+		time.Now(),
+		&mock.MessageHandlerStub{},
+		&mock.HeartbeatStorerStub{},
+		th,
+	)
+
+	assert.Nil(t, mon)
+	assert.Equal(t, heartbeat.ErrInvalidHysteresisDuration, err)
+}
+
 func TestNewMonitor_OkValsShouldCreatePubkeyMap(t *testing.T) {
 	t.Parallel()
 
@@ -112,6 +213,9 @@ func TestNewMonitor_OkValsShouldCreatePubkeyMap(t *testing.T) {
 	mon, err := heartbeat.NewMonitor(
 		&mock.MarshalizerMock{},
 		1,
+		1,
+		0,
+		0,
 		map[uint32][]string{0: {"pk1", "pk2"}},
 		time.Now(),
 		&mock.MessageHandlerStub{},
@@ -151,6 +255,9 @@ func TestNewMonitor_ShouldComputeShardId(t *testing.T) {
 	mon, err := heartbeat.NewMonitor(
 		&mock.MarshalizerMock{},
 		maxDuration,
+		maxDuration,
+		0,
+		0,
 		pksPerShards,
 		time.Now(),
 		&mock.MessageHandlerStub{},
@@ -195,6 +302,9 @@ func TestMonitor_ProcessReceivedMessageShouldWork(t *testing.T) {
 			},
 		},
 		time.Second*1000,
+		time.Second*1000,
+		0,
+		0,
 		map[uint32][]string{0: {pubKey}},
 		time.Now(),
 		&mock.MessageHandlerStub{
@@ -253,6 +363,9 @@ func TestMonitor_ProcessReceivedMessageWithNewPublicKey(t *testing.T) {
 			},
 		},
 		time.Second*1000,
+		time.Second*1000,
+		0,
+		0,
 		map[uint32][]string{0: {"pk2"}},
 		time.Now(),
 		&mock.MessageHandlerStub{
@@ -315,6 +428,9 @@ func TestMonitor_ProcessReceivedMessageWithNewShardID(t *testing.T) {
 			},
 		},
 		time.Second*1000,
+		time.Second*1000,
+		0,
+		0,
 		map[uint32][]string{0: {"pk1"}},
 		time.Now(),
 		&mock.MessageHandlerStub{
@@ -402,6 +518,9 @@ func TestMonitor_ProcessReceivedMessageShouldSetPeerInactive(t *testing.T) {
 			},
 		},
 		time.Second*5,
+		time.Second*5,
+		0,
+		0,
 		map[uint32][]string{0: {pubKey1, pubKey2}},
 		th.Now(),
 		&mock.MessageHandlerStub{
@@ -455,3 +574,445 @@ func sendHbMessageFromPubKey(pubKey string, mon *heartbeat.Monitor) error {
 	err := mon.ProcessReceivedMessage(&mock.P2PMessageStub{DataField: buffToSend}, nil)
 	return err
 }
+
+//------- SetPeerIgnored
+
+func TestMonitor_SetPeerIgnoredUnknownPeerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	mon, _ := heartbeat.NewMonitor(
+		&mock.MarshalizerMock{},
+		time.Second,
+		time.Second,
+		0,
+		0,
+		map[uint32][]string{0: {"pk1"}},
+		time.Now(),
+		&mock.MessageHandlerStub{},
+		&mock.HeartbeatStorerStub{
+			UpdateGenesisTimeCalled: func(genesisTime time.Time) error {
+				return nil
+			},
+			LoadHbmiDTOCalled: func(pubKey string) (*heartbeat.HeartbeatDTO, error) {
+				return nil, errors.New("not found")
+			},
+			LoadKeysCalled: func() ([][]byte, error) {
+				return nil, nil
+			},
+		},
+		&mock.MockTimer{},
+	)
+
+	err := mon.SetPeerIgnored([]byte("unknown pk"), true)
+
+	assert.Equal(t, heartbeat.ErrPeerNotFound, err)
+}
+
+func TestMonitor_IgnoredPeerShouldBeExcludedFromMetrics(t *testing.T) {
+	t.Parallel()
+
+	pubKey1 := "pk1"
+	pubKey2 := "pk2-ignored"
+	th := &mock.MockTimer{}
+	mon, _ := heartbeat.NewMonitor(
+		&mock.MarshalizerMock{},
+		time.Second*5,
+		time.Second*5,
+		0,
+		0,
+		map[uint32][]string{0: {pubKey1, pubKey2}},
+		th.Now(),
+		&mock.MessageHandlerStub{},
+		&mock.HeartbeatStorerStub{
+			UpdateGenesisTimeCalled: func(genesisTime time.Time) error {
+				return nil
+			},
+			LoadHbmiDTOCalled: func(pubKey string) (*heartbeat.HeartbeatDTO, error) {
+				return nil, errors.New("not found")
+			},
+			LoadKeysCalled: func() ([][]byte, error) {
+				return nil, nil
+			},
+			SavePubkeyDataCalled: func(pubkey []byte, heartbeat *heartbeat.HeartbeatDTO) error {
+				return nil
+			},
+			SaveKeysCalled: func(peersSlice [][]byte) error {
+				return nil
+			},
+		},
+		th,
+	)
+
+	metrics := make(map[string]uint64)
+	mutMetrics := sync.Mutex{}
+	_ = mon.SetAppStatusHandler(&mock.AppStatusHandlerStub{
+		SetUInt64ValueHandler: func(key string, value uint64) {
+			mutMetrics.Lock()
+			metrics[key] = value
+			mutMetrics.Unlock()
+		},
+	})
+
+	mon.AddHeartbeatMessageToMap(&heartbeat.Heartbeat{Pubkey: []byte(pubKey1)})
+	mon.AddHeartbeatMessageToMap(&heartbeat.Heartbeat{Pubkey: []byte(pubKey2)})
+
+	_ = mon.GetHeartbeats()
+	mutMetrics.Lock()
+	assert.Equal(t, uint64(2), metrics[core.MetricConnectedNodes])
+	mutMetrics.Unlock()
+
+	err := mon.SetPeerIgnored([]byte(pubKey2), true)
+	assert.Nil(t, err)
+
+	hbStatus := mon.GetHeartbeats()
+	assert.Equal(t, 2, len(hbStatus))
+	mutMetrics.Lock()
+	assert.Equal(t, uint64(1), metrics[core.MetricConnectedNodes])
+	assert.Equal(t, uint64(1), metrics[core.MetricLiveValidatorNodes])
+	mutMetrics.Unlock()
+}
+
+func TestMonitor_SaveHeartbeatsToStorageShouldSaveAllPeers(t *testing.T) {
+	t.Parallel()
+
+	mutSaved := sync.Mutex{}
+	savedPubKeys := make(map[string]*heartbeat.HeartbeatDTO)
+	mon, _ := heartbeat.NewMonitor(
+		&mock.MarshalizerMock{},
+		time.Second,
+		time.Second,
+		0,
+		0,
+		map[uint32][]string{0: {"pk1", "pk2"}},
+		time.Time{},
+		&mock.MessageHandlerStub{},
+		&mock.HeartbeatStorerStub{
+			UpdateGenesisTimeCalled: func(genesisTime time.Time) error {
+				return nil
+			},
+			LoadHbmiDTOCalled: func(pubKey string) (*heartbeat.HeartbeatDTO, error) {
+				return nil, errors.New("not found")
+			},
+			LoadKeysCalled: func() ([][]byte, error) {
+				return nil, nil
+			},
+			SavePubkeyDataCalled: func(pubkey []byte, hb *heartbeat.HeartbeatDTO) error {
+				mutSaved.Lock()
+				savedPubKeys[string(pubkey)] = hb
+				mutSaved.Unlock()
+				return nil
+			},
+		},
+		&mock.MockTimer{},
+	)
+
+	err := mon.SaveHeartbeatsToStorage()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(savedPubKeys))
+	assert.NotNil(t, savedPubKeys["pk1"])
+	assert.NotNil(t, savedPubKeys["pk2"])
+}
+
+func TestMonitor_SaveHeartbeatsToStorageStorerErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	mon, _ := heartbeat.NewMonitor(
+		&mock.MarshalizerMock{},
+		time.Second,
+		time.Second,
+		0,
+		0,
+		map[uint32][]string{0: {"pk1"}},
+		time.Time{},
+		&mock.MessageHandlerStub{},
+		&mock.HeartbeatStorerStub{
+			UpdateGenesisTimeCalled: func(genesisTime time.Time) error {
+				return nil
+			},
+			LoadHbmiDTOCalled: func(pubKey string) (*heartbeat.HeartbeatDTO, error) {
+				return nil, errors.New("not found")
+			},
+			LoadKeysCalled: func() ([][]byte, error) {
+				return nil, nil
+			},
+			SavePubkeyDataCalled: func(pubkey []byte, hb *heartbeat.HeartbeatDTO) error {
+				return expectedErr
+			},
+		},
+		&mock.MockTimer{},
+	)
+
+	err := mon.SaveHeartbeatsToStorage()
+
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestMonitor_SetStatusChangeHandlerNilHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	mon, _ := heartbeat.NewMonitor(
+		&mock.MarshalizerMock{},
+		time.Second,
+		time.Second,
+		0,
+		0,
+		map[uint32][]string{0: {"pk1"}},
+		time.Time{},
+		&mock.MessageHandlerStub{},
+		&mock.HeartbeatStorerStub{
+			UpdateGenesisTimeCalled: func(genesisTime time.Time) error {
+				return nil
+			},
+			LoadHbmiDTOCalled: func(pubKey string) (*heartbeat.HeartbeatDTO, error) {
+				return nil, errors.New("not found")
+			},
+			LoadKeysCalled: func() ([][]byte, error) {
+				return nil, nil
+			},
+		},
+		&mock.MockTimer{},
+	)
+
+	err := mon.SetStatusChangeHandler(nil)
+
+	assert.Equal(t, heartbeat.ErrNilStatusChangeHandler, err)
+}
+
+func TestMonitor_StatusChangeHandlerShouldBeCalledWhenPeerBecomesInactive(t *testing.T) {
+	t.Parallel()
+
+	pubKey := "pk1"
+	th := &mock.MockTimer{}
+	mon, _ := heartbeat.NewMonitor(
+		&mock.MarshalizerMock{},
+		time.Second*10,
+		time.Second*10,
+		0,
+		0,
+		map[uint32][]string{0: {pubKey}},
+		time.Time{},
+		&mock.MessageHandlerStub{
+			CreateHeartbeatFromP2pMessageCalled: func(message p2p.MessageP2P) (*heartbeat.Heartbeat, error) {
+				var rcvHb heartbeat.Heartbeat
+				_ = json.Unmarshal(message.Data(), &rcvHb)
+				return &rcvHb, nil
+			},
+		},
+		&mock.HeartbeatStorerStub{
+			UpdateGenesisTimeCalled: func(genesisTime time.Time) error {
+				return nil
+			},
+			LoadHbmiDTOCalled: func(pubKey string) (*heartbeat.HeartbeatDTO, error) {
+				return nil, errors.New("not found")
+			},
+			LoadKeysCalled: func() ([][]byte, error) {
+				return nil, nil
+			},
+			SavePubkeyDataCalled: func(pubkey []byte, heartbeat *heartbeat.HeartbeatDTO) error {
+				return nil
+			},
+			SaveKeysCalled: func(peersSlice [][]byte) error {
+				return nil
+			},
+		},
+		th,
+	)
+
+	mutTransitions := sync.Mutex{}
+	transitions := make(map[string][]bool)
+	_ = mon.SetStatusChangeHandler(func(pk string, isActive bool) {
+		mutTransitions.Lock()
+		transitions[pk] = append(transitions[pk], isActive)
+		mutTransitions.Unlock()
+	})
+
+	hbBytes, _ := json.Marshal(heartbeat.Heartbeat{Pubkey: []byte(pubKey)})
+	_ = mon.ProcessReceivedMessage(&mock.P2PMessageStub{DataField: hbBytes}, nil)
+
+	//a delay is mandatory for the go routine to finish its job
+	time.Sleep(time.Second)
+
+	th.IncrementSeconds(20)
+	_ = mon.GetHeartbeats()
+
+	mutTransitions.Lock()
+	defer mutTransitions.Unlock()
+	assert.Equal(t, []bool{true, false}, transitions[hex.EncodeToString([]byte(pubKey))])
+}
+
+func TestMonitor_ProcessReceivedMessageTimestampFarInFutureShouldStillProcess(t *testing.T) {
+	t.Parallel()
+
+	pubKey := "pk1"
+	th := &mock.MockTimer{}
+	th.SetSeconds(1000)
+	mon, _ := heartbeat.NewMonitor(
+		&mock.MarshalizerMock{},
+		time.Second*1000,
+		time.Second*1000,
+		0,
+		0,
+		map[uint32][]string{0: {"pk2"}},
+		time.Unix(0, 0),
+		&mock.MessageHandlerStub{
+			CreateHeartbeatFromP2pMessageCalled: func(message p2p.MessageP2P) (*heartbeat.Heartbeat, error) {
+				var rcvHb heartbeat.Heartbeat
+				_ = json.Unmarshal(message.Data(), &rcvHb)
+				return &rcvHb, nil
+			},
+		},
+		&mock.HeartbeatStorerStub{
+			UpdateGenesisTimeCalled: func(genesisTime time.Time) error {
+				return nil
+			},
+			LoadHbmiDTOCalled: func(pubKey string) (*heartbeat.HeartbeatDTO, error) {
+				return nil, errors.New("not found")
+			},
+			LoadKeysCalled: func() ([][]byte, error) {
+				return nil, nil
+			},
+			SavePubkeyDataCalled: func(pubkey []byte, heartbeat *heartbeat.HeartbeatDTO) error {
+				return nil
+			},
+			SaveKeysCalled: func(peersSlice [][]byte) error {
+				return nil
+			},
+		},
+		th,
+	)
+
+	hb := heartbeat.Heartbeat{
+		Pubkey:    []byte(pubKey),
+		Timestamp: th.Now().Add(time.Hour).Unix(),
+	}
+	hbBytes, _ := json.Marshal(hb)
+	err := mon.ProcessReceivedMessage(&mock.P2PMessageStub{DataField: hbBytes}, nil)
+	assert.Nil(t, err)
+
+	//a delay is mandatory for the go routines to finish their job
+	time.Sleep(time.Millisecond * 100)
+
+	hbStatus := mon.GetHeartbeats()
+	assert.Equal(t, 2, len(hbStatus))
+	assert.Equal(t, hex.EncodeToString([]byte(pubKey)), hbStatus[0].HexPublicKey)
+}
+
+func TestMonitor_SetMaxAllowedTimeSkewInvalidValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	mon, _ := heartbeat.NewMonitor(
+		&mock.MarshalizerMock{},
+		time.Second,
+		time.Second,
+		0,
+		0,
+		map[uint32][]string{0: {"pk1"}},
+		time.Time{},
+		&mock.MessageHandlerStub{},
+		&mock.HeartbeatStorerStub{
+			UpdateGenesisTimeCalled: func(genesisTime time.Time) error {
+				return nil
+			},
+			LoadHbmiDTOCalled: func(pubKey string) (*heartbeat.HeartbeatDTO, error) {
+				return nil, errors.New("not found")
+			},
+			LoadKeysCalled: func() ([][]byte, error) {
+				return nil, nil
+			},
+		},
+		&mock.MockTimer{},
+	)
+
+	err := mon.SetMaxAllowedTimeSkew(0)
+
+	assert.Equal(t, heartbeat.ErrInvalidMaxAllowedTimeSkew, err)
+}
+
+func TestMonitor_SetMaxAllowedTimeSkewShouldWork(t *testing.T) {
+	t.Parallel()
+
+	mon, _ := heartbeat.NewMonitor(
+		&mock.MarshalizerMock{},
+		time.Second,
+		time.Second,
+		0,
+		0,
+		map[uint32][]string{0: {"pk1"}},
+		time.Time{},
+		&mock.MessageHandlerStub{},
+		&mock.HeartbeatStorerStub{
+			UpdateGenesisTimeCalled: func(genesisTime time.Time) error {
+				return nil
+			},
+			LoadHbmiDTOCalled: func(pubKey string) (*heartbeat.HeartbeatDTO, error) {
+				return nil, errors.New("not found")
+			},
+			LoadKeysCalled: func() ([][]byte, error) {
+				return nil, nil
+			},
+		},
+		&mock.MockTimer{},
+	)
+
+	err := mon.SetMaxAllowedTimeSkew(time.Minute)
+
+	assert.Nil(t, err)
+	assert.Equal(t, time.Minute, mon.MaxAllowedTimeSkew())
+}
+
+func TestMonitor_GracePeriodShouldDelayPeerInactive(t *testing.T) {
+	t.Parallel()
+
+	pubKey := "pk1"
+	th := &mock.MockTimer{}
+	mon, _ := heartbeat.NewMonitor(
+		&mock.MarshalizerMock{},
+		time.Second*10,
+		time.Second*10,
+		time.Second*10,
+		0,
+		map[uint32][]string{0: {pubKey}},
+		time.Time{},
+		&mock.MessageHandlerStub{
+			CreateHeartbeatFromP2pMessageCalled: func(message p2p.MessageP2P) (*heartbeat.Heartbeat, error) {
+				var rcvHb heartbeat.Heartbeat
+				_ = json.Unmarshal(message.Data(), &rcvHb)
+				return &rcvHb, nil
+			},
+		},
+		&mock.HeartbeatStorerStub{
+			UpdateGenesisTimeCalled: func(genesisTime time.Time) error {
+				return nil
+			},
+			LoadHbmiDTOCalled: func(pubKey string) (*heartbeat.HeartbeatDTO, error) {
+				return nil, errors.New("not found")
+			},
+			LoadKeysCalled: func() ([][]byte, error) {
+				return nil, nil
+			},
+			SavePubkeyDataCalled: func(pubkey []byte, heartbeat *heartbeat.HeartbeatDTO) error {
+				return nil
+			},
+			SaveKeysCalled: func(peersSlice [][]byte) error {
+				return nil
+			},
+		},
+		th,
+	)
+
+	hbBytes, _ := json.Marshal(heartbeat.Heartbeat{Pubkey: []byte(pubKey)})
+	_ = mon.ProcessReceivedMessage(&mock.P2PMessageStub{DataField: hbBytes}, nil)
+
+	//a delay is mandatory for the go routine to finish its job
+	time.Sleep(time.Second)
+
+	th.IncrementSeconds(15)
+	hbStatus := mon.GetHeartbeats()
+	assert.True(t, hbStatus[0].IsActive)
+
+	th.IncrementSeconds(10)
+	hbStatus = mon.GetHeartbeats()
+	assert.False(t, hbStatus[0].IsActive)
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/ElrondNetwork/elrond-go/crypto"
+	"github.com/ElrondNetwork/elrond-go/data"
 	"github.com/ElrondNetwork/elrond-go/marshal"
 	"github.com/ElrondNetwork/elrond-go/sharding"
 )
@@ -17,8 +18,10 @@ type Sender struct {
 	marshalizer      marshal.Marshalizer
 	topic            string
 	shardCoordinator sharding.Coordinator
+	blockChain       data.ChainHandler
 	versionNumber    string
 	nodeDisplayName  string
+	bootTimestamp    int64
 }
 
 // NewSender will create a new sender instance
@@ -29,6 +32,7 @@ func NewSender(
 	marshalizer marshal.Marshalizer,
 	topic string,
 	shardCoordinator sharding.Coordinator,
+	blockChain data.ChainHandler,
 	versionNumber string,
 	nodeDisplayName string,
 ) (*Sender, error) {
@@ -48,6 +52,9 @@ func NewSender(
 	if shardCoordinator == nil {
 		return nil, ErrNilShardCoordinator
 	}
+	if blockChain == nil || blockChain.IsInterfaceNil() {
+		return nil, ErrNilBlockChain
+	}
 
 	sender := &Sender{
 		peerMessenger:    peerMessenger,
@@ -56,8 +63,10 @@ func NewSender(
 		marshalizer:      marshalizer,
 		topic:            topic,
 		shardCoordinator: shardCoordinator,
+		blockChain:       blockChain,
 		versionNumber:    versionNumber,
 		nodeDisplayName:  nodeDisplayName,
+		bootTimestamp:    time.Now().Unix(),
 	}
 
 	return sender, nil
@@ -71,6 +80,9 @@ func (s *Sender) SendHeartbeat() error {
 		ShardID:         s.shardCoordinator.SelfId(),
 		VersionNumber:   s.versionNumber,
 		NodeDisplayName: s.nodeDisplayName,
+		BootTimestamp:   s.bootTimestamp,
+		Timestamp:       time.Now().Unix(),
+		Nonce:           s.currentNonce(),
 	}
 
 	var err error
@@ -98,3 +110,13 @@ func (s *Sender) SendHeartbeat() error {
 
 	return nil
 }
+
+// currentNonce returns the nonce of the current block header or 0 if no block was committed yet
+func (s *Sender) currentNonce() uint64 {
+	header := s.blockChain.GetCurrentBlockHeader()
+	if header == nil || header.IsInterfaceNil() {
+		return 0
+	}
+
+	return header.GetNonce()
+}
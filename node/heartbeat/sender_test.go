@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/ElrondNetwork/elrond-go/crypto"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
 	"github.com/ElrondNetwork/elrond-go/node/heartbeat"
 	"github.com/ElrondNetwork/elrond-go/node/mock"
 	"github.com/stretchr/testify/assert"
@@ -23,6 +25,7 @@ func TestNewSender_NilP2pMessengerShouldErr(t *testing.T) {
 		&mock.MarshalizerMock{},
 		"",
 		&mock.ShardCoordinatorMock{},
+		&mock.BlockChainMock{},
 		"v0.1",
 		"undefined",
 	)
@@ -41,6 +44,7 @@ func TestNewSender_NilSingleSignerShouldErr(t *testing.T) {
 		&mock.MarshalizerMock{},
 		"",
 		&mock.ShardCoordinatorMock{},
+		&mock.BlockChainMock{},
 		"v0.1",
 		"undefined",
 	)
@@ -59,6 +63,7 @@ func TestNewSender_NilShardCoordinatorShouldErr(t *testing.T) {
 		&mock.MarshalizerMock{},
 		"",
 		nil,
+		&mock.BlockChainMock{},
 		"v0.1",
 		"undefined",
 	)
@@ -77,6 +82,7 @@ func TestNewSender_NilPrivateKeyShouldErr(t *testing.T) {
 		&mock.MarshalizerMock{},
 		"",
 		&mock.ShardCoordinatorMock{},
+		&mock.BlockChainMock{},
 		"v0.1",
 		"undefined",
 	)
@@ -95,6 +101,7 @@ func TestNewSender_NilMarshalizerShouldErr(t *testing.T) {
 		nil,
 		"",
 		&mock.ShardCoordinatorMock{},
+		&mock.BlockChainMock{},
 		"v0.1",
 		"undefined",
 	)
@@ -103,6 +110,25 @@ func TestNewSender_NilMarshalizerShouldErr(t *testing.T) {
 	assert.Equal(t, heartbeat.ErrNilMarshalizer, err)
 }
 
+func TestNewSender_NilBlockChainShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sender, err := heartbeat.NewSender(
+		&mock.MessengerStub{},
+		&mock.SinglesignStub{},
+		&mock.PrivateKeyStub{},
+		&mock.MarshalizerMock{},
+		"",
+		&mock.ShardCoordinatorMock{},
+		nil,
+		"v0.1",
+		"undefined",
+	)
+
+	assert.Nil(t, sender)
+	assert.Equal(t, heartbeat.ErrNilBlockChain, err)
+}
+
 func TestNewSender_ShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -113,6 +139,7 @@ func TestNewSender_ShouldWork(t *testing.T) {
 		&mock.MarshalizerMock{},
 		"",
 		&mock.ShardCoordinatorMock{},
+		&mock.BlockChainMock{},
 		"v0.1",
 		"undefined",
 	)
@@ -155,6 +182,7 @@ func TestSender_SendHeartbeatGeneratePublicKeyErrShouldErr(t *testing.T) {
 		},
 		"",
 		&mock.ShardCoordinatorMock{},
+		&mock.BlockChainMock{},
 		"v0.1",
 		"undefined",
 	)
@@ -196,6 +224,7 @@ func TestSender_SendHeartbeatSignErrShouldErr(t *testing.T) {
 		},
 		"",
 		&mock.ShardCoordinatorMock{},
+		&mock.BlockChainMock{},
 		"v0.1",
 		"undefined",
 	)
@@ -237,6 +266,7 @@ func TestSender_SendHeartbeatMarshalizerErrShouldErr(t *testing.T) {
 		},
 		"",
 		&mock.ShardCoordinatorMock{},
+		&mock.BlockChainMock{},
 		"v0.1",
 		"undefined",
 	)
@@ -300,6 +330,7 @@ func TestSender_SendHeartbeatShouldWork(t *testing.T) {
 		},
 		testTopic,
 		&mock.ShardCoordinatorMock{},
+		&mock.BlockChainMock{},
 		"v0.1",
 		"undefined",
 	)
@@ -312,3 +343,50 @@ func TestSender_SendHeartbeatShouldWork(t *testing.T) {
 	assert.True(t, genPubKeyClled)
 	assert.True(t, marshalCalled)
 }
+
+func TestSender_SendHeartbeatShouldSetTheCurrentBlockNonce(t *testing.T) {
+	t.Parallel()
+
+	crtNonce := uint64(37)
+	var sentNonce uint64
+	sender, _ := heartbeat.NewSender(
+		&mock.MessengerStub{
+			BroadcastCalled: func(topic string, buff []byte) {
+			},
+		},
+		&mock.SinglesignStub{
+			SignCalled: func(private crypto.PrivateKey, msg []byte) (i []byte, e error) {
+				return nil, nil
+			},
+		},
+		&mock.PrivateKeyStub{
+			GeneratePublicHandler: func() crypto.PublicKey {
+				return &mock.PublicKeyMock{
+					ToByteArrayHandler: func() (i []byte, e error) {
+						return []byte("pub key"), nil
+					},
+				}
+			},
+		},
+		&mock.MarshalizerMock{
+			MarshalHandler: func(obj interface{}) (i []byte, e error) {
+				sentNonce = obj.(*heartbeat.Heartbeat).Nonce
+				return nil, nil
+			},
+		},
+		"",
+		&mock.ShardCoordinatorMock{},
+		&mock.BlockChainMock{
+			GetCurrentBlockHeaderCalled: func() data.HeaderHandler {
+				return &block.Header{Nonce: crtNonce}
+			},
+		},
+		"v0.1",
+		"undefined",
+	)
+
+	err := sender.SendHeartbeat()
+
+	assert.Nil(t, err)
+	assert.Equal(t, crtNonce, sentNonce)
+}
@@ -0,0 +1,120 @@
+package heartbeat
+
+import (
+	"time"
+)
+
+// DefaultUptimeWindow is the default length of the recent history used when computing the uptime percentage
+const DefaultUptimeWindow = 24 * time.Hour
+
+// maxUptimeWindowIntervals bounds the number of up/down intervals retained for a single peer
+const maxUptimeWindowIntervals = 1024
+
+const initialUptimeWindowIntervals = 8
+
+type availabilityInterval struct {
+	start time.Time
+	end   time.Time
+	isUp  bool
+}
+
+// uptimeWindow retains, in a ring buffer, the up and down intervals of a peer that overlap the last window duration
+type uptimeWindow struct {
+	window    time.Duration
+	intervals []availabilityInterval
+	first     int
+	size      int
+}
+
+func newUptimeWindow(window time.Duration) *uptimeWindow {
+	return &uptimeWindow{
+		window:    window,
+		intervals: make([]availabilityInterval, initialUptimeWindowIntervals),
+	}
+}
+
+func (uw *uptimeWindow) at(idx int) *availabilityInterval {
+	return &uw.intervals[(uw.first+idx)%len(uw.intervals)]
+}
+
+// add records a new interval, merging it with the last one if they are contiguous and have the same state
+func (uw *uptimeWindow) add(start time.Time, end time.Time, isUp bool) {
+	if !end.After(start) {
+		return
+	}
+
+	if uw.size > 0 {
+		last := uw.at(uw.size - 1)
+		if last.isUp == isUp && last.end.Equal(start) {
+			last.end = end
+			uw.prune(end)
+			return
+		}
+	}
+
+	if uw.size == len(uw.intervals) {
+		if len(uw.intervals) < maxUptimeWindowIntervals {
+			uw.grow()
+		} else {
+			uw.first = (uw.first + 1) % len(uw.intervals)
+			uw.size--
+		}
+	}
+	*uw.at(uw.size) = availabilityInterval{start: start, end: end, isUp: isUp}
+	uw.size++
+
+	uw.prune(end)
+}
+
+func (uw *uptimeWindow) grow() {
+	newLen := 2 * len(uw.intervals)
+	if newLen > maxUptimeWindowIntervals {
+		newLen = maxUptimeWindowIntervals
+	}
+
+	intervals := make([]availabilityInterval, newLen)
+	for i := 0; i < uw.size; i++ {
+		intervals[i] = *uw.at(i)
+	}
+
+	uw.intervals = intervals
+	uw.first = 0
+}
+
+func (uw *uptimeWindow) prune(crtTime time.Time) {
+	windowStart := crtTime.Add(-uw.window)
+	for uw.size > 0 && !uw.at(0).end.After(windowStart) {
+		uw.first = (uw.first + 1) % len(uw.intervals)
+		uw.size--
+	}
+}
+
+// upAndDown returns the up and down durations recorded between crtTime - window and crtTime
+func (uw *uptimeWindow) upAndDown(crtTime time.Time) (time.Duration, time.Duration) {
+	windowStart := crtTime.Add(-uw.window)
+	up := time.Duration(0)
+	down := time.Duration(0)
+
+	for i := 0; i < uw.size; i++ {
+		interval := uw.at(i)
+		duration := overlappingDuration(interval.start, interval.end, windowStart, crtTime)
+		if interval.isUp {
+			up += duration
+		} else {
+			down += duration
+		}
+	}
+
+	return up, down
+}
+
+func overlappingDuration(start time.Time, end time.Time, windowStart time.Time, windowEnd time.Time) time.Duration {
+	if start.Before(windowStart) {
+		start = windowStart
+	}
+	if end.After(windowEnd) {
+		end = windowEnd
+	}
+
+	return maxDuration(0, end.Sub(start))
+}
@@ -157,6 +157,13 @@ func (n *Node) Stop() error {
 	if !n.IsRunning() {
 		return nil
 	}
+	if n.heartbeatMonitor != nil {
+		err := n.heartbeatMonitor.SaveHeartbeatsToStorage()
+		if err != nil {
+			log.Warn("cannot save heartbeats to storage: " + err.Error())
+		}
+	}
+
 	err := n.messenger.Close()
 	if err != nil {
 		return err
@@ -734,6 +741,7 @@ func (n *Node) StartHeartbeat(hbConfig config.HeartbeatConfig, versionNumber str
 		n.marshalizer,
 		HeartbeatTopic,
 		n.shardCoordinator,
+		n.blkc,
 		versionNumber,
 		nodeDisplayName,
 	)
@@ -755,6 +763,9 @@ func (n *Node) StartHeartbeat(hbConfig config.HeartbeatConfig, versionNumber str
 	n.heartbeatMonitor, err = heartbeat.NewMonitor(
 		n.marshalizer,
 		time.Second*time.Duration(hbConfig.DurationInSecToConsiderUnresponsive),
+		time.Second*time.Duration(durationInSecToConsiderValidatorUnresponsive(hbConfig)),
+		time.Second*time.Duration(hbConfig.GracePeriodInSec),
+		time.Second*time.Duration(hbConfig.SettlePeriodInSec),
 		n.initialNodesPubkeys,
 		n.genesisTime,
 		heartBeatMsgProcessor,
@@ -770,6 +781,13 @@ func (n *Node) StartHeartbeat(hbConfig config.HeartbeatConfig, versionNumber str
 		return err
 	}
 
+	if hbConfig.MaxAllowedTimeSkewInSec > 0 {
+		err = n.heartbeatMonitor.SetMaxAllowedTimeSkew(time.Second * time.Duration(hbConfig.MaxAllowedTimeSkewInSec))
+		if err != nil {
+			return err
+		}
+	}
+
 	err = n.messenger.RegisterMessageProcessor(HeartbeatTopic, n.heartbeatMonitor)
 	if err != nil {
 		return err
@@ -796,10 +814,39 @@ func (n *Node) checkConfigParams(config config.HeartbeatConfig) error {
 	if config.DurationInSecToConsiderUnresponsive <= config.MaxTimeToWaitBetweenBroadcastsInSec {
 		return ErrWrongValues
 	}
+	if config.DurationInSecToConsiderValidatorUnresponsive < 0 {
+		return ErrNegativeDurationInSecToConsiderValidatorUnresponsive
+	}
+	if config.DurationInSecToConsiderValidatorUnresponsive > config.DurationInSecToConsiderUnresponsive {
+		return ErrWrongValues
+	}
+	if config.DurationInSecToConsiderValidatorUnresponsive > 0 &&
+		config.DurationInSecToConsiderValidatorUnresponsive <= config.MaxTimeToWaitBetweenBroadcastsInSec {
+		return ErrWrongValues
+	}
+	if config.GracePeriodInSec < 0 {
+		return ErrNegativeGracePeriodInSec
+	}
+	if config.SettlePeriodInSec < 0 {
+		return ErrNegativeSettlePeriodInSec
+	}
+	if config.MaxAllowedTimeSkewInSec < 0 {
+		return ErrNegativeMaxAllowedTimeSkewInSec
+	}
 
 	return nil
 }
 
+// durationInSecToConsiderValidatorUnresponsive returns the validators threshold, falling back to the peers
+// threshold when it is not set
+func durationInSecToConsiderValidatorUnresponsive(config config.HeartbeatConfig) int {
+	if config.DurationInSecToConsiderValidatorUnresponsive == 0 {
+		return config.DurationInSecToConsiderUnresponsive
+	}
+
+	return config.DurationInSecToConsiderValidatorUnresponsive
+}
+
 func (n *Node) startSendingHeartbeats(config config.HeartbeatConfig) {
 	r := rand.New(rand.NewSource(time.Now().Unix()))
 
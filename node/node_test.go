@@ -992,6 +992,108 @@ func TestNode_StartHeartbeatInvalidMaxTimeDurationShouldErr(t *testing.T) {
 	assert.Equal(t, node.ErrWrongValues, err)
 }
 
+func TestNode_StartHeartbeatNegativeValidatorDurationShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode()
+	err := n.StartHeartbeat(config.HeartbeatConfig{
+		MinTimeToWaitBetweenBroadcastsInSec:          1,
+		MaxTimeToWaitBetweenBroadcastsInSec:          2,
+		DurationInSecToConsiderUnresponsive:          3,
+		DurationInSecToConsiderValidatorUnresponsive: -1,
+		Enabled: true,
+	}, "v0.1",
+		"undefined",
+	)
+
+	assert.Equal(t, node.ErrNegativeDurationInSecToConsiderValidatorUnresponsive, err)
+}
+
+func TestNode_StartHeartbeatValidatorDurationGreaterThanDurationShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode()
+	err := n.StartHeartbeat(config.HeartbeatConfig{
+		MinTimeToWaitBetweenBroadcastsInSec:          1,
+		MaxTimeToWaitBetweenBroadcastsInSec:          2,
+		DurationInSecToConsiderUnresponsive:          3,
+		DurationInSecToConsiderValidatorUnresponsive: 4,
+		Enabled: true,
+	}, "v0.1",
+		"undefined",
+	)
+
+	assert.Equal(t, node.ErrWrongValues, err)
+}
+
+func TestNode_StartHeartbeatValidatorDurationNotGreaterThanMaxBroadcastIntervalShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode()
+	err := n.StartHeartbeat(config.HeartbeatConfig{
+		MinTimeToWaitBetweenBroadcastsInSec:          1,
+		MaxTimeToWaitBetweenBroadcastsInSec:          2,
+		DurationInSecToConsiderUnresponsive:          3,
+		DurationInSecToConsiderValidatorUnresponsive: 2,
+		Enabled: true,
+	}, "v0.1",
+		"undefined",
+	)
+
+	assert.Equal(t, node.ErrWrongValues, err)
+}
+
+func TestNode_StartHeartbeatNegativeGracePeriodShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode()
+	err := n.StartHeartbeat(config.HeartbeatConfig{
+		MinTimeToWaitBetweenBroadcastsInSec: 1,
+		MaxTimeToWaitBetweenBroadcastsInSec: 2,
+		DurationInSecToConsiderUnresponsive: 3,
+		GracePeriodInSec:                    -1,
+		Enabled:                             true,
+	}, "v0.1",
+		"undefined",
+	)
+
+	assert.Equal(t, node.ErrNegativeGracePeriodInSec, err)
+}
+
+func TestNode_StartHeartbeatNegativeSettlePeriodShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode()
+	err := n.StartHeartbeat(config.HeartbeatConfig{
+		MinTimeToWaitBetweenBroadcastsInSec: 1,
+		MaxTimeToWaitBetweenBroadcastsInSec: 2,
+		DurationInSecToConsiderUnresponsive: 3,
+		SettlePeriodInSec:                   -1,
+		Enabled:                             true,
+	}, "v0.1",
+		"undefined",
+	)
+
+	assert.Equal(t, node.ErrNegativeSettlePeriodInSec, err)
+}
+
+func TestNode_StartHeartbeatNegativeMaxAllowedTimeSkewShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode()
+	err := n.StartHeartbeat(config.HeartbeatConfig{
+		MinTimeToWaitBetweenBroadcastsInSec: 1,
+		MaxTimeToWaitBetweenBroadcastsInSec: 2,
+		DurationInSecToConsiderUnresponsive: 3,
+		MaxAllowedTimeSkewInSec:             -1,
+		Enabled:                             true,
+	}, "v0.1",
+		"undefined",
+	)
+
+	assert.Equal(t, node.ErrNegativeMaxAllowedTimeSkewInSec, err)
+}
+
 func TestNode_StartHeartbeatNilMarshalizerShouldErr(t *testing.T) {
 	t.Parallel()
 
@@ -1015,6 +1117,7 @@ func TestNode_StartHeartbeatNilMarshalizerShouldErr(t *testing.T) {
 		node.WithInitialNodesPubKeys(map[uint32][]string{0: {"pk1"}}),
 		node.WithPrivKey(&mock.PrivateKeyStub{}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithBlockChain(&mock.BlockChainMock{}),
 		node.WithDataStore(&mock.ChainStorerMock{
 			GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
 				return mock.NewStorerMock()
@@ -1057,6 +1160,7 @@ func TestNode_StartHeartbeatNilKeygenShouldErr(t *testing.T) {
 		node.WithInitialNodesPubKeys(map[uint32][]string{0: {"pk1"}}),
 		node.WithPrivKey(&mock.PrivateKeyStub{}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithBlockChain(&mock.BlockChainMock{}),
 		node.WithDataStore(&mock.ChainStorerMock{
 			GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
 				return mock.NewStorerMock()
@@ -1091,6 +1195,7 @@ func TestNode_StartHeartbeatHasTopicValidatorShouldErr(t *testing.T) {
 		node.WithInitialNodesPubKeys(map[uint32][]string{0: {"pk1"}}),
 		node.WithTxSignPrivKey(&mock.PrivateKeyStub{}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithBlockChain(&mock.BlockChainMock{}),
 		node.WithDataStore(&mock.ChainStorerMock{
 			GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
 				return mock.NewStorerMock()
@@ -1131,6 +1236,7 @@ func TestNode_StartHeartbeatCreateTopicFailsShouldErr(t *testing.T) {
 		node.WithInitialNodesPubKeys(map[uint32][]string{0: {"pk1"}}),
 		node.WithTxSignPrivKey(&mock.PrivateKeyStub{}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithBlockChain(&mock.BlockChainMock{}),
 		node.WithDataStore(&mock.ChainStorerMock{
 			GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
 				return mock.NewStorerMock()
@@ -1174,6 +1280,7 @@ func TestNode_StartHeartbeatRegisterMessageProcessorFailsShouldErr(t *testing.T)
 		node.WithInitialNodesPubKeys(map[uint32][]string{0: {"pk1"}}),
 		node.WithPrivKey(&mock.PrivateKeyStub{}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithBlockChain(&mock.BlockChainMock{}),
 		node.WithDataStore(&mock.ChainStorerMock{
 			GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
 				return mock.NewStorerMock()
@@ -1236,6 +1343,7 @@ func TestNode_StartHeartbeatShouldWorkAndCallSendHeartbeat(t *testing.T) {
 			},
 		}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithBlockChain(&mock.BlockChainMock{}),
 		node.WithDataStore(&mock.ChainStorerMock{
 			GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
 				return mock.NewStorerMock()
@@ -1294,6 +1402,7 @@ func TestNode_StartHeartbeatShouldWorkAndHaveAllPublicKeys(t *testing.T) {
 			},
 		}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithBlockChain(&mock.BlockChainMock{}),
 		node.WithDataStore(&mock.ChainStorerMock{
 			GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
 				return mock.NewStorerMock()
@@ -1353,6 +1462,7 @@ func TestNode_StartHeartbeatShouldSetNodesFromInitialPubKeysAsValidators(t *test
 			},
 		}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithBlockChain(&mock.BlockChainMock{}),
 		node.WithDataStore(&mock.ChainStorerMock{
 			GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
 				return mock.NewStorerMock()
@@ -1417,6 +1527,7 @@ func TestNode_StartHeartbeatShouldWorkAndCanCallProcessMessage(t *testing.T) {
 			},
 		}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithBlockChain(&mock.BlockChainMock{}),
 		node.WithDataStore(&mock.ChainStorerMock{
 			GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
 				return mock.NewStorerMock()
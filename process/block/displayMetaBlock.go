@@ -1,19 +1,28 @@
 package block
 
 import (
+	"bytes"
 	"encoding/base64"
+	"encoding/csv"
 	"fmt"
 	"sync"
 
 	"github.com/ElrondNetwork/elrond-go/core"
 	"github.com/ElrondNetwork/elrond-go/data/block"
 	"github.com/ElrondNetwork/elrond-go/display"
+	"github.com/ElrondNetwork/elrond-go/process"
 )
 
+var metaBlockCSVHeader = []string{"ShardId", "HeaderHash", "SenderShardId", "ReceiverShardId", "MiniBlockHash", "TxCount", "Invalid"}
+
 type headersCounter struct {
 	shardMBHeaderCounterMutex           sync.RWMutex
 	shardMBHeadersCurrentBlockProcessed uint64
 	shardMBHeadersTotalProcessed        uint64
+
+	mutDisplayConfig       sync.RWMutex
+	displayMBHeaderTxCount bool
+	numShards              uint32
 }
 
 // NewHeaderCounter returns a new object that keeps track of how many headers
@@ -26,6 +35,22 @@ func NewHeaderCounter() *headersCounter {
 	}
 }
 
+// SetDisplayMiniBlockHeaderTxCount enables or disables the output of the number of transactions
+// referenced by each displayed shard miniblock header
+func (hc *headersCounter) SetDisplayMiniBlockHeaderTxCount(enabled bool) {
+	hc.mutDisplayConfig.Lock()
+	hc.displayMBHeaderTxCount = enabled
+	hc.mutDisplayConfig.Unlock()
+}
+
+// SetNumShards sets the number of shards used to flag the displayed shard data having an out of range
+// shard id. A value of 0 disables the check
+func (hc *headersCounter) SetNumShards(numShards uint32) {
+	hc.mutDisplayConfig.Lock()
+	hc.numShards = numShards
+	hc.mutDisplayConfig.Unlock()
+}
+
 func (hc *headersCounter) subtractRestoredMBHeaders(numMiniBlockHeaders int) {
 	hc.shardMBHeaderCounterMutex.Lock()
 	hc.shardMBHeadersTotalProcessed -= uint64(numMiniBlockHeaders)
@@ -96,43 +121,180 @@ func (hc *headersCounter) createDisplayableMetaHeader(
 	return tableHeader, metaLines
 }
 
-func (hc *headersCounter) displayShardInfo(lines []*display.LineData, header *block.MetaBlock) []*display.LineData {
-	for i := 0; i < len(header.ShardInfo); i++ {
-		shardData := header.ShardInfo[i]
+// shardInfoEntry is a shard miniblock header of a meta block together with its shard data, as seen with the current
+// display configuration. A shard data without miniblock headers is seen as a single entry having a nil mbHeader
+type shardInfoEntry struct {
+	shardData *block.ShardData
+	isInvalid bool
+	mbIndex   int
+	mbHeader  *block.ShardMiniBlockHeader
+	txCount   uint32
+}
 
-		lines = append(lines, display.NewLineData(false, []string{
-			fmt.Sprintf("ShardData_%d", shardData.ShardId),
-			"Header hash",
-			base64.StdEncoding.EncodeToString(shardData.HeaderHash)}))
+func (entry *shardInfoEntry) isLastOfShard() bool {
+	return entry.mbHeader == nil || entry.mbIndex == len(entry.shardData.ShardMiniBlockHeaders)-1
+}
 
-		if shardData.ShardMiniBlockHeaders == nil || len(shardData.ShardMiniBlockHeaders) == 0 {
-			lines = append(lines, display.NewLineData(false, []string{
-				"", "ShardMiniBlockHeaders", "<EMPTY>"}))
+// iterateShardInfo calls the handler for each shard info entry of the provided meta block. The tx count of an
+// entry is set only if its display is enabled. The iteration stops at the first error returned by the handler
+func (hc *headersCounter) iterateShardInfo(header *block.MetaBlock, handler func(entry *shardInfoEntry) error) error {
+	hc.mutDisplayConfig.RLock()
+	displayTxCount := hc.displayMBHeaderTxCount
+	numShards := hc.numShards
+	hc.mutDisplayConfig.RUnlock()
+
+	for i := 0; i < len(header.ShardInfo); i++ {
+		shardData := &header.ShardInfo[i]
+		entry := &shardInfoEntry{
+			shardData: shardData,
+			isInvalid: numShards > 0 && shardData.ShardId >= numShards,
+		}
+
+		if len(shardData.ShardMiniBlockHeaders) == 0 {
+			err := handler(entry)
+			if err != nil {
+				return err
+			}
+			continue
 		}
 
 		for j := 0; j < len(shardData.ShardMiniBlockHeaders); j++ {
-			if j == 0 || j >= len(shardData.ShardMiniBlockHeaders)-1 {
-				senderShard := shardData.ShardMiniBlockHeaders[j].SenderShardId
-				receiverShard := shardData.ShardMiniBlockHeaders[j].ReceiverShardId
-
-				lines = append(lines, display.NewLineData(false, []string{
-					"",
-					fmt.Sprintf("%d ShardMiniBlockHeaderHash_%d_%d", j+1, senderShard, receiverShard),
-					core.ToB64(shardData.ShardMiniBlockHeaders[j].Hash)}))
-			} else if j == 1 {
-				lines = append(lines, display.NewLineData(false, []string{
-					"",
-					fmt.Sprintf("..."),
-					fmt.Sprintf("...")}))
+			entry.mbIndex = j
+			entry.mbHeader = &shardData.ShardMiniBlockHeaders[j]
+			entry.txCount = 0
+			if displayTxCount {
+				entry.txCount = entry.mbHeader.TxCount
+			}
+
+			err := handler(entry)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (hc *headersCounter) displayShardInfo(lines []*display.LineData, header *block.MetaBlock) []*display.LineData {
+	_ = hc.iterateShardInfo(header, func(entry *shardInfoEntry) error {
+		if entry.mbIndex == 0 {
+			part := fmt.Sprintf("ShardData_%d", entry.shardData.ShardId)
+			if entry.isInvalid {
+				part += " (INVALID)"
 			}
+
+			lines = append(lines, display.NewLineData(false, []string{
+				part,
+				"Header hash",
+				base64.StdEncoding.EncodeToString(entry.shardData.HeaderHash)}))
 		}
 
-		lines[len(lines)-1].HorizontalRuleAfter = true
+		lines = appendMiniBlockHeaderLine(lines, entry)
+		if entry.isLastOfShard() {
+			lines[len(lines)-1].HorizontalRuleAfter = true
+		}
+
+		return nil
+	})
+
+	return lines
+}
+
+// appendMiniBlockHeaderLine appends the line of the provided entry. Only the first and the last miniblock headers
+// of a shard data are displayed, the ones in between being replaced by a single "..." line
+func appendMiniBlockHeaderLine(lines []*display.LineData, entry *shardInfoEntry) []*display.LineData {
+	if entry.mbHeader == nil {
+		return append(lines, display.NewLineData(false, []string{
+			"", "ShardMiniBlockHeaders", "<EMPTY>"}))
+	}
+
+	if entry.mbIndex == 0 || entry.isLastOfShard() {
+		parameter := fmt.Sprintf("%d ShardMiniBlockHeaderHash_%d_%d",
+			entry.mbIndex+1,
+			entry.mbHeader.SenderShardId,
+			entry.mbHeader.ReceiverShardId)
+		if entry.txCount > 0 {
+			parameter += fmt.Sprintf(" (%d txs)", entry.txCount)
+		}
+
+		return append(lines, display.NewLineData(false, []string{
+			"",
+			parameter,
+			core.ToB64(entry.mbHeader.Hash)}))
+	}
+
+	if entry.mbIndex == 1 {
+		return append(lines, display.NewLineData(false, []string{
+			"",
+			fmt.Sprintf("..."),
+			fmt.Sprintf("...")}))
 	}
 
 	return lines
 }
 
+// MetaBlockToCSV serializes the shard info of the provided meta block as CSV, one row for each shard miniblock
+// header. A shard data without miniblock headers is output as a single row with empty miniblock columns. The tx
+// count and the invalid shard id columns follow the same display configuration as the logged table
+func (hc *headersCounter) MetaBlockToCSV(header *block.MetaBlock) ([]byte, error) {
+	if header == nil {
+		return nil, process.ErrNilMetaBlockHeader
+	}
+
+	buff := &bytes.Buffer{}
+	csvWriter := csv.NewWriter(buff)
+
+	err := csvWriter.Write(metaBlockCSVHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	err = hc.iterateShardInfo(header, func(entry *shardInfoEntry) error {
+		return csvWriter.Write(shardInfoEntryToCSVRecord(entry))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	csvWriter.Flush()
+	err = csvWriter.Error()
+	if err != nil {
+		return nil, err
+	}
+
+	return buff.Bytes(), nil
+}
+
+func shardInfoEntryToCSVRecord(entry *shardInfoEntry) []string {
+	invalid := ""
+	if entry.isInvalid {
+		invalid = "INVALID"
+	}
+
+	record := []string{
+		fmt.Sprintf("%d", entry.shardData.ShardId),
+		core.ToB64(entry.shardData.HeaderHash),
+		"",
+		"",
+		"",
+		"",
+		invalid,
+	}
+	if entry.mbHeader == nil {
+		return record
+	}
+
+	record[2] = fmt.Sprintf("%d", entry.mbHeader.SenderShardId)
+	record[3] = fmt.Sprintf("%d", entry.mbHeader.ReceiverShardId)
+	record[4] = core.ToB64(entry.mbHeader.Hash)
+	if entry.txCount > 0 {
+		record[5] = fmt.Sprintf("%d", entry.txCount)
+	}
+
+	return record
+}
+
 func (hc *headersCounter) getNumShardMBHeadersTotalProcessed() uint64 {
 	hc.shardMBHeaderCounterMutex.Lock()
 	defer hc.shardMBHeaderCounterMutex.Unlock()
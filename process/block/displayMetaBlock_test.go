@@ -0,0 +1,163 @@
+package block
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/display"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/stretchr/testify/assert"
+)
+
+func createMetaBlockWithShardInfo() *block.MetaBlock {
+	return &block.MetaBlock{
+		Nonce: 1,
+		ShardInfo: []block.ShardData{
+			{
+				ShardId:    0,
+				HeaderHash: []byte("header hash 0"),
+				ShardMiniBlockHeaders: []block.ShardMiniBlockHeader{
+					{Hash: []byte("mb hash 0"), SenderShardId: 0, ReceiverShardId: 1, TxCount: 5},
+					{Hash: []byte("mb hash 1"), SenderShardId: 0, ReceiverShardId: 0, TxCount: 0},
+				},
+			},
+			{
+				ShardId:    1,
+				HeaderHash: []byte("header hash 1"),
+				ShardMiniBlockHeaders: []block.ShardMiniBlockHeader{
+					{Hash: []byte("mb hash 2"), SenderShardId: 1, ReceiverShardId: 0, TxCount: 7},
+				},
+			},
+		},
+	}
+}
+
+func TestDisplayMetaBlock_DisplayShardInfoWithoutTxCountShouldNotRenderIt(t *testing.T) {
+	t.Parallel()
+
+	hc := NewHeaderCounter()
+	lines := hc.displayShardInfo(make([]*display.LineData, 0), createMetaBlockWithShardInfo())
+
+	assert.Equal(t, 5, len(lines))
+	assert.Equal(t, "1 ShardMiniBlockHeaderHash_0_1", lines[1].Values[1])
+	assert.Equal(t, "2 ShardMiniBlockHeaderHash_0_0", lines[2].Values[1])
+	assert.Equal(t, "1 ShardMiniBlockHeaderHash_1_0", lines[4].Values[1])
+}
+
+func TestDisplayMetaBlock_DisplayShardInfoWithTxCountShouldRenderIt(t *testing.T) {
+	t.Parallel()
+
+	hc := NewHeaderCounter()
+	hc.SetDisplayMiniBlockHeaderTxCount(true)
+	lines := hc.displayShardInfo(make([]*display.LineData, 0), createMetaBlockWithShardInfo())
+
+	assert.Equal(t, 5, len(lines))
+	assert.Equal(t, "1 ShardMiniBlockHeaderHash_0_1 (5 txs)", lines[1].Values[1])
+	assert.Equal(t, "2 ShardMiniBlockHeaderHash_0_0", lines[2].Values[1])
+	assert.Equal(t, "1 ShardMiniBlockHeaderHash_1_0 (7 txs)", lines[4].Values[1])
+}
+
+func TestDisplayMetaBlock_MetaBlockToCSVNilHeaderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	csvBytes, err := NewHeaderCounter().MetaBlockToCSV(nil)
+
+	assert.Nil(t, csvBytes)
+	assert.Equal(t, process.ErrNilMetaBlockHeader, err)
+}
+
+func TestDisplayMetaBlock_MetaBlockToCSVShouldMatchGoldenFile(t *testing.T) {
+	t.Parallel()
+
+	expected, err := ioutil.ReadFile(filepath.Join("testdata", "metaBlockTwoShards.csv"))
+	assert.Nil(t, err)
+
+	csvBytes, err := NewHeaderCounter().MetaBlockToCSV(createMetaBlockWithShardInfo())
+
+	assert.Nil(t, err)
+	assert.Equal(t, string(expected), string(csvBytes))
+}
+
+func TestDisplayMetaBlock_MetaBlockToCSVShouldFollowDisplayConfig(t *testing.T) {
+	t.Parallel()
+
+	header := createMetaBlockWithShardInfo()
+	header.ShardInfo[1].ShardId = 7
+	header.ShardInfo = append(header.ShardInfo, block.ShardData{ShardId: 1, HeaderHash: []byte("header hash 2")})
+
+	hc := NewHeaderCounter()
+	hc.SetDisplayMiniBlockHeaderTxCount(true)
+	hc.SetNumShards(2)
+	csvBytes, err := hc.MetaBlockToCSV(header)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "ShardId,HeaderHash,SenderShardId,ReceiverShardId,MiniBlockHash,TxCount,Invalid\n"+
+		"0,aGVhZGVyIGhhc2ggMA==,0,1,bWIgaGFzaCAw,5,\n"+
+		"0,aGVhZGVyIGhhc2ggMA==,0,0,bWIgaGFzaCAx,,\n"+
+		"7,aGVhZGVyIGhhc2ggMQ==,1,0,bWIgaGFzaCAy,7,INVALID\n"+
+		"1,aGVhZGVyIGhhc2ggMg==,,,,,\n",
+		string(csvBytes),
+	)
+}
+
+func TestDisplayMetaBlock_DisplayShardInfoOutOfRangeShardIdShouldBeMarked(t *testing.T) {
+	t.Parallel()
+
+	header := createMetaBlockWithShardInfo()
+	header.ShardInfo[1].ShardId = 7
+
+	hc := NewHeaderCounter()
+	hc.SetNumShards(2)
+	lines := hc.displayShardInfo(make([]*display.LineData, 0), header)
+
+	assert.Equal(t, "ShardData_0", lines[0].Values[0])
+	assert.Equal(t, "ShardData_7 (INVALID)", lines[3].Values[0])
+}
+
+func TestDisplayMetaBlock_DisplayShardInfoShouldElideMiddleMiniBlockHeaders(t *testing.T) {
+	t.Parallel()
+
+	header := &block.MetaBlock{
+		ShardInfo: []block.ShardData{
+			{
+				ShardId:    0,
+				HeaderHash: []byte("header hash 0"),
+				ShardMiniBlockHeaders: []block.ShardMiniBlockHeader{
+					{Hash: []byte("mb hash 0")},
+					{Hash: []byte("mb hash 1")},
+					{Hash: []byte("mb hash 2")},
+					{Hash: []byte("mb hash 3")},
+				},
+			},
+			{
+				ShardId:    1,
+				HeaderHash: []byte("header hash 1"),
+			},
+		},
+	}
+
+	hc := NewHeaderCounter()
+	lines := hc.displayShardInfo(make([]*display.LineData, 0), header)
+
+	assert.Equal(t, 6, len(lines))
+	assert.Equal(t, "1 ShardMiniBlockHeaderHash_0_0", lines[1].Values[1])
+	assert.Equal(t, "...", lines[2].Values[1])
+	assert.Equal(t, "4 ShardMiniBlockHeaderHash_0_0", lines[3].Values[1])
+	assert.True(t, lines[3].HorizontalRuleAfter)
+	assert.Equal(t, "<EMPTY>", lines[5].Values[2])
+	assert.True(t, lines[5].HorizontalRuleAfter)
+}
+
+func TestDisplayMetaBlock_DisplayShardInfoZeroNumShardsShouldNotMark(t *testing.T) {
+	t.Parallel()
+
+	header := createMetaBlockWithShardInfo()
+	header.ShardInfo[1].ShardId = 7
+
+	hc := NewHeaderCounter()
+	lines := hc.displayShardInfo(make([]*display.LineData, 0), header)
+
+	assert.Equal(t, "ShardData_7", lines[3].Values[0])
+}
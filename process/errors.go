@@ -502,3 +502,12 @@ var ErrInvalidRewardsPercentages = errors.New("invalid rewards percentages")
 
 // ErrNilMiniBlocksCompacter signals that a nil mini blocks compacter has been provided
 var ErrNilMiniBlocksCompacter = errors.New("nil mini blocks compacter")
+
+// ErrSenderRateLimitExceeded signals that a message was dropped because its sender exceeded the allowed rate
+var ErrSenderRateLimitExceeded = errors.New("sender rate limit exceeded")
+
+// ErrNilSenderRateLimiter signals that a nil sender rate limiter has been provided
+var ErrNilSenderRateLimiter = errors.New("nil sender rate limiter")
+
+// ErrInvalidSenderRateLimit signals that an invalid sender rate or burst has been provided
+var ErrInvalidSenderRateLimit = errors.New("invalid sender rate limit")
@@ -17,6 +17,7 @@ import (
 	processInterceptors "github.com/ElrondNetwork/elrond-go/process/interceptors"
 	interceptorFactory "github.com/ElrondNetwork/elrond-go/process/interceptors/factory"
 	"github.com/ElrondNetwork/elrond-go/process/interceptors/processor"
+	"github.com/ElrondNetwork/elrond-go/process/throttle"
 	"github.com/ElrondNetwork/elrond-go/sharding"
 )
 
@@ -41,6 +42,8 @@ type interceptorsContainerFactory struct {
 	tpsBenchmark           *statistics.TpsBenchmark
 	argInterceptorFactory  *interceptorFactory.ArgInterceptedDataFactory
 	globalThrottler        process.InterceptorThrottler
+	senderRatePerSecond    float64
+	senderBurst            uint32
 }
 
 // NewInterceptorsContainerFactory is responsible for creating a new interceptors factory object
@@ -233,6 +236,11 @@ func (icf *interceptorsContainerFactory) generateMetablockInterceptor() ([]strin
 		return nil, nil, err
 	}
 
+	err = icf.setSenderRateLimiter(interceptor)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	_, err = icf.createTopicAndAssignHandler(identifierHdr, interceptor, true)
 	if err != nil {
 		return nil, nil, err
@@ -299,6 +307,11 @@ func (icf *interceptorsContainerFactory) createOneShardHeaderInterceptor(topic s
 		return nil, err
 	}
 
+	err = icf.setSenderRateLimiter(interceptor)
+	if err != nil {
+		return nil, err
+	}
+
 	return icf.createTopicAndAssignHandler(topic, interceptor, true)
 }
 
@@ -435,9 +448,40 @@ func (icf *interceptorsContainerFactory) createOneMiniBlocksInterceptor(topic st
 		return nil, err
 	}
 
+	err = icf.setSenderRateLimiter(interceptor)
+	if err != nil {
+		return nil, err
+	}
+
 	return icf.createTopicAndAssignHandler(topic, interceptor, true)
 }
 
+// SetSenderRateLimit enables the per sender rate limiting on the header and miniblock interceptors, each
+// interceptor getting its own limiter. Should be called before Create
+func (icf *interceptorsContainerFactory) SetSenderRateLimit(ratePerSecond float64, burst uint32) error {
+	if ratePerSecond <= 0 || burst == 0 {
+		return process.ErrInvalidSenderRateLimit
+	}
+
+	icf.senderRatePerSecond = ratePerSecond
+	icf.senderBurst = burst
+
+	return nil
+}
+
+func (icf *interceptorsContainerFactory) setSenderRateLimiter(interceptor *processInterceptors.SingleDataInterceptor) error {
+	if icf.senderRatePerSecond <= 0 {
+		return nil
+	}
+
+	senderRateLimiter, err := throttle.NewSenderRateLimiter(icf.senderRatePerSecond, icf.senderBurst)
+	if err != nil {
+		return err
+	}
+
+	return interceptor.SetSenderRateLimiter(senderRateLimiter)
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (icf *interceptorsContainerFactory) IsInterfaceNil() bool {
 	if icf == nil {
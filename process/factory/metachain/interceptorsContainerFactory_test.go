@@ -608,3 +608,130 @@ func TestInterceptorsContainerFactory_With4ShardsShouldWork(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, totalInterceptors, container.Len())
 }
+
+func TestInterceptorsContainerFactory_SetSenderRateLimitInvalidValuesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	icf, _ := metachain.NewInterceptorsContainerFactory(
+		mock.NewOneShardCoordinatorMock(),
+		mock.NewNodesCoordinatorMock(),
+		createStubTopicHandler("", ""),
+		createStore(),
+		&mock.MarshalizerMock{},
+		&mock.HasherMock{},
+		mock.NewMultiSigner(),
+		createDataPools(),
+		&mock.AccountsStub{},
+		&mock.AddressConverterMock{},
+		&mock.SignerMock{},
+		&mock.SingleSignKeyGenMock{},
+		maxTxNonceDeltaAllowed,
+		&mock.FeeHandlerStub{},
+	)
+
+	assert.Equal(t, process.ErrInvalidSenderRateLimit, icf.SetSenderRateLimit(0, 1))
+	assert.Equal(t, process.ErrInvalidSenderRateLimit, icf.SetSenderRateLimit(1, 0))
+	assert.Nil(t, icf.SetSenderRateLimit(1, 1))
+}
+
+func TestInterceptorsContainerFactory_CreateWithSenderRateLimitShouldLimitHeadersAndMiniBlocks(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+	handlers := make(map[string]p2p.MessageProcessor)
+	icf, _ := metachain.NewInterceptorsContainerFactory(
+		shardCoordinator,
+		mock.NewNodesCoordinatorMock(),
+		&mock.TopicHandlerStub{
+			CreateTopicCalled: func(name string, createChannelForTopic bool) error {
+				return nil
+			},
+			RegisterMessageProcessorCalled: func(topic string, handler p2p.MessageProcessor) error {
+				handlers[topic] = handler
+				return nil
+			},
+		},
+		createStore(),
+		&mock.MarshalizerMock{},
+		&mock.HasherMock{},
+		mock.NewMultiSigner(),
+		createDataPools(),
+		&mock.AccountsStub{},
+		&mock.AddressConverterMock{},
+		&mock.SignerMock{},
+		&mock.SingleSignKeyGenMock{},
+		maxTxNonceDeltaAllowed,
+		&mock.FeeHandlerStub{},
+	)
+	_ = icf.SetSenderRateLimit(1, 1)
+
+	_, err := icf.Create()
+	assert.Nil(t, err)
+
+	shardIdentifier := shardCoordinator.CommunicationIdentifier(0)
+	limitedTopics := []string{
+		factory.MetachainBlocksTopic,
+		factory.ShardHeadersForMetachainTopic + shardIdentifier,
+		factory.MiniBlocksTopic + shardIdentifier,
+	}
+	msg := &mock.P2PMessageMock{
+		DataField: []byte("data"),
+		PeerField: "sender",
+	}
+	for _, topic := range limitedTopics {
+		assert.NotEqual(t, process.ErrSenderRateLimitExceeded, handlers[topic].ProcessReceivedMessage(msg, nil))
+		assert.Equal(t, process.ErrSenderRateLimitExceeded, handlers[topic].ProcessReceivedMessage(msg, nil))
+	}
+
+	txTopic := factory.TransactionTopic + shardIdentifier
+	assert.NotEqual(t, process.ErrSenderRateLimitExceeded, handlers[txTopic].ProcessReceivedMessage(msg, nil))
+	assert.NotEqual(t, process.ErrSenderRateLimitExceeded, handlers[txTopic].ProcessReceivedMessage(msg, nil))
+}
+func TestInterceptorsContainerFactory_CreateWithoutSenderRateLimitShouldNotLimitSyncResponses(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+	handlers := make(map[string]p2p.MessageProcessor)
+	icf, _ := metachain.NewInterceptorsContainerFactory(
+		shardCoordinator,
+		mock.NewNodesCoordinatorMock(),
+		&mock.TopicHandlerStub{
+			CreateTopicCalled: func(name string, createChannelForTopic bool) error {
+				return nil
+			},
+			RegisterMessageProcessorCalled: func(topic string, handler p2p.MessageProcessor) error {
+				handlers[topic] = handler
+				return nil
+			},
+		},
+		createStore(),
+		&mock.MarshalizerMock{},
+		&mock.HasherMock{},
+		mock.NewMultiSigner(),
+		createDataPools(),
+		&mock.AccountsStub{},
+		&mock.AddressConverterMock{},
+		&mock.SignerMock{},
+		&mock.SingleSignKeyGenMock{},
+		maxTxNonceDeltaAllowed,
+		&mock.FeeHandlerStub{},
+	)
+
+	_, err := icf.Create()
+	assert.Nil(t, err)
+
+	//while syncing, all the requested headers and miniblocks come from the same peer in a short amount of time
+	msg := &mock.P2PMessageMock{
+		DataField: []byte("data"),
+		PeerField: "peer the node syncs from",
+	}
+	for _, topic := range []string{
+		factory.MetachainBlocksTopic,
+		factory.ShardHeadersForMetachainTopic + shardCoordinator.CommunicationIdentifier(0),
+		factory.MiniBlocksTopic + shardCoordinator.CommunicationIdentifier(0),
+	} {
+		for i := 0; i < 100; i++ {
+			assert.NotEqual(t, process.ErrSenderRateLimitExceeded, handlers[topic].ProcessReceivedMessage(msg, nil))
+		}
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/process/interceptors/processor"
 	"github.com/ElrondNetwork/elrond-go/process/mock"
 	"github.com/ElrondNetwork/elrond-go/process/rewardTransaction"
+	"github.com/ElrondNetwork/elrond-go/process/throttle"
 	"github.com/ElrondNetwork/elrond-go/sharding"
 )
 
@@ -37,6 +38,8 @@ type interceptorsContainerFactory struct {
 	argInterceptorFactory  *interceptorFactory.ArgInterceptedDataFactory
 	globalTxThrottler      process.InterceptorThrottler
 	maxTxNonceDeltaAllowed int
+	senderRatePerSecond    float64
+	senderBurst            uint32
 }
 
 // NewInterceptorsContainerFactory is responsible for creating a new interceptors factory object
@@ -449,6 +452,11 @@ func (icf *interceptorsContainerFactory) generateHdrInterceptor() ([]string, []p
 		return nil, nil, err
 	}
 
+	err = icf.setSenderRateLimiter(interceptor)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	identifierHdr := factory.HeadersTopic + shardC.CommunicationIdentifier(shardC.SelfId())
 	_, err = icf.createTopicAndAssignHandler(identifierHdr, interceptor, true)
 	if err != nil {
@@ -520,6 +528,11 @@ func (icf *interceptorsContainerFactory) createOneMiniBlocksInterceptor(topic st
 		return nil, err
 	}
 
+	err = icf.setSenderRateLimiter(interceptor)
+	if err != nil {
+		return nil, err
+	}
+
 	return icf.createTopicAndAssignHandler(topic, interceptor, true)
 }
 
@@ -562,6 +575,11 @@ func (icf *interceptorsContainerFactory) generateMetachainHeaderInterceptor() ([
 		return nil, nil, err
 	}
 
+	err = icf.setSenderRateLimiter(interceptor)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	_, err = icf.createTopicAndAssignHandler(identifierHdr, interceptor, true)
 	if err != nil {
 		return nil, nil, err
@@ -570,6 +588,32 @@ func (icf *interceptorsContainerFactory) generateMetachainHeaderInterceptor() ([
 	return []string{identifierHdr}, []process.Interceptor{interceptor}, nil
 }
 
+// SetSenderRateLimit enables the per sender rate limiting on the header and miniblock interceptors, each
+// interceptor getting its own limiter. Should be called before Create
+func (icf *interceptorsContainerFactory) SetSenderRateLimit(ratePerSecond float64, burst uint32) error {
+	if ratePerSecond <= 0 || burst == 0 {
+		return process.ErrInvalidSenderRateLimit
+	}
+
+	icf.senderRatePerSecond = ratePerSecond
+	icf.senderBurst = burst
+
+	return nil
+}
+
+func (icf *interceptorsContainerFactory) setSenderRateLimiter(interceptor *interceptors.SingleDataInterceptor) error {
+	if icf.senderRatePerSecond <= 0 {
+		return nil
+	}
+
+	senderRateLimiter, err := throttle.NewSenderRateLimiter(icf.senderRatePerSecond, icf.senderBurst)
+	if err != nil {
+		return err
+	}
+
+	return interceptor.SetSenderRateLimiter(senderRateLimiter)
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (icf *interceptorsContainerFactory) IsInterfaceNil() bool {
 	if icf == nil {
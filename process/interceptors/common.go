@@ -8,13 +8,20 @@ import (
 	"github.com/ElrondNetwork/elrond-go/process"
 )
 
-func preProcessMesage(throttler process.InterceptorThrottler, message p2p.MessageP2P) error {
+func preProcessMesage(
+	throttler process.InterceptorThrottler,
+	senderRateLimiter process.SenderRateLimiter,
+	message p2p.MessageP2P,
+) error {
 	if message == nil {
 		return process.ErrNilMessage
 	}
 	if message.Data() == nil {
 		return process.ErrNilDataToProcess
 	}
+	if senderRateLimiter != nil && !senderRateLimiter.Allow(message.Peer()) {
+		return process.ErrSenderRateLimitExceeded
+	}
 
 	if !throttler.CanProcess() {
 		return process.ErrSystemBusy
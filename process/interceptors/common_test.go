@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ElrondNetwork/elrond-go/p2p"
 	"github.com/ElrondNetwork/elrond-go/process"
 	"github.com/ElrondNetwork/elrond-go/process/mock"
 	"github.com/stretchr/testify/assert"
@@ -15,7 +16,7 @@ import (
 func TestPreProcessMessage_NilMessageShouldErr(t *testing.T) {
 	t.Parallel()
 
-	err := preProcessMesage(&mock.InterceptorThrottlerStub{}, nil)
+	err := preProcessMesage(&mock.InterceptorThrottlerStub{}, nil, nil)
 
 	assert.Equal(t, process.ErrNilMessage, err)
 }
@@ -24,7 +25,7 @@ func TestPreProcessMessage_NilDataShouldErr(t *testing.T) {
 	t.Parallel()
 
 	msg := &mock.P2PMessageMock{}
-	err := preProcessMesage(&mock.InterceptorThrottlerStub{}, msg)
+	err := preProcessMesage(&mock.InterceptorThrottlerStub{}, nil, msg)
 
 	assert.Equal(t, process.ErrNilDataToProcess, err)
 }
@@ -41,7 +42,7 @@ func TestPreProcessMessage_CanNotProcessShouldErr(t *testing.T) {
 		},
 	}
 
-	err := preProcessMesage(throttler, msg)
+	err := preProcessMesage(throttler, nil, msg)
 
 	assert.Equal(t, process.ErrSystemBusy, err)
 }
@@ -57,7 +58,58 @@ func TestPreProcessMessage_CanProcessReturnsNilAndCallsStartProcessing(t *testin
 			return true
 		},
 	}
-	err := preProcessMesage(throttler, msg)
+	err := preProcessMesage(throttler, nil, msg)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), throttler.StartProcessingCount())
+}
+
+func TestPreProcessMessage_SenderRateLimitExceededShouldErr(t *testing.T) {
+	t.Parallel()
+
+	msg := &mock.P2PMessageMock{
+		DataField: []byte("data to process"),
+		PeerField: "sender",
+	}
+	throttler := &mock.InterceptorThrottlerStub{
+		CanProcessCalled: func() bool {
+			return true
+		},
+	}
+	checkedSender := p2p.PeerID("")
+	senderRateLimiter := &mock.SenderRateLimiterStub{
+		AllowCalled: func(sender p2p.PeerID) bool {
+			checkedSender = sender
+			return false
+		},
+	}
+
+	err := preProcessMesage(throttler, senderRateLimiter, msg)
+
+	assert.Equal(t, process.ErrSenderRateLimitExceeded, err)
+	assert.Equal(t, p2p.PeerID("sender"), checkedSender)
+	assert.Equal(t, int32(0), throttler.StartProcessingCount())
+}
+
+func TestPreProcessMessage_SenderRateLimitAllowedShouldCallStartProcessing(t *testing.T) {
+	t.Parallel()
+
+	msg := &mock.P2PMessageMock{
+		DataField: []byte("data to process"),
+		PeerField: "sender",
+	}
+	throttler := &mock.InterceptorThrottlerStub{
+		CanProcessCalled: func() bool {
+			return true
+		},
+	}
+	senderRateLimiter := &mock.SenderRateLimiterStub{
+		AllowCalled: func(sender p2p.PeerID) bool {
+			return true
+		},
+	}
+
+	err := preProcessMesage(throttler, senderRateLimiter, msg)
 
 	assert.Nil(t, err)
 	assert.Equal(t, int32(1), throttler.StartProcessingCount())
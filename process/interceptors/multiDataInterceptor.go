@@ -18,6 +18,9 @@ type MultiDataInterceptor struct {
 	factory     process.InterceptedDataFactory
 	processor   process.InterceptorProcessor
 	throttler   process.InterceptorThrottler
+
+	mutSenderRateLimiter sync.RWMutex
+	senderRateLimiter    process.SenderRateLimiter
 }
 
 // NewMultiDataInterceptor hooks a new interceptor for packed multi data
@@ -51,10 +54,28 @@ func NewMultiDataInterceptor(
 	return multiDataIntercept, nil
 }
 
+// SetSenderRateLimiter sets the component used to drop the messages of the senders that send too fast, before
+// the received data is unmarshalled and checked
+func (mdi *MultiDataInterceptor) SetSenderRateLimiter(senderRateLimiter process.SenderRateLimiter) error {
+	if check.IfNil(senderRateLimiter) {
+		return process.ErrNilSenderRateLimiter
+	}
+
+	mdi.mutSenderRateLimiter.Lock()
+	mdi.senderRateLimiter = senderRateLimiter
+	mdi.mutSenderRateLimiter.Unlock()
+
+	return nil
+}
+
 // ProcessReceivedMessage is the callback func from the p2p.Messenger and will be called each time a new message was received
 // (for the topic this validator was registered to)
 func (mdi *MultiDataInterceptor) ProcessReceivedMessage(message p2p.MessageP2P, broadcastHandler func(buffToSend []byte)) error {
-	err := preProcessMesage(mdi.throttler, message)
+	mdi.mutSenderRateLimiter.RLock()
+	senderRateLimiter := mdi.senderRateLimiter
+	mdi.mutSenderRateLimiter.RUnlock()
+
+	err := preProcessMesage(mdi.throttler, senderRateLimiter, message)
 	if err != nil {
 		return err
 	}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ElrondNetwork/elrond-go/core/check"
+	"github.com/ElrondNetwork/elrond-go/p2p"
 	"github.com/ElrondNetwork/elrond-go/process"
 	"github.com/ElrondNetwork/elrond-go/process/interceptors"
 	"github.com/ElrondNetwork/elrond-go/process/mock"
@@ -101,6 +102,54 @@ func TestMultiDataInterceptor_ProcessReceivedMessageNilMessageShouldErr(t *testi
 	assert.Equal(t, process.ErrNilMessage, err)
 }
 
+func TestMultiDataInterceptor_SetSenderRateLimiterNilShouldErr(t *testing.T) {
+	t.Parallel()
+
+	mdi, _ := interceptors.NewMultiDataInterceptor(
+		&mock.MarshalizerMock{},
+		&mock.InterceptedDataFactoryStub{},
+		&mock.InterceptorProcessorStub{},
+		createMockThrottler(),
+	)
+
+	err := mdi.SetSenderRateLimiter(nil)
+
+	assert.Equal(t, process.ErrNilSenderRateLimiter, err)
+}
+
+func TestMultiDataInterceptor_ProcessReceivedMessageSenderRateExceededShouldDropBeforeUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	unmarshalCalled := false
+	throttler := createMockThrottler()
+	mdi, _ := interceptors.NewMultiDataInterceptor(
+		&mock.MarshalizerStub{
+			UnmarshalCalled: func(obj interface{}, buff []byte) error {
+				unmarshalCalled = true
+				return nil
+			},
+		},
+		&mock.InterceptedDataFactoryStub{},
+		&mock.InterceptorProcessorStub{},
+		throttler,
+	)
+	_ = mdi.SetSenderRateLimiter(&mock.SenderRateLimiterStub{
+		AllowCalled: func(sender p2p.PeerID) bool {
+			return false
+		},
+	})
+
+	msg := &mock.P2PMessageMock{
+		DataField: []byte("data to be processed"),
+		PeerField: "flooder",
+	}
+	err := mdi.ProcessReceivedMessage(msg, nil)
+
+	assert.Equal(t, process.ErrSenderRateLimitExceeded, err)
+	assert.False(t, unmarshalCalled)
+	assert.Equal(t, int32(0), throttler.StartProcessingCount())
+}
+
 func TestMultiDataInterceptor_ProcessReceivedMessageUnmarshalFailsShouldErr(t *testing.T) {
 	t.Parallel()
 
@@ -13,4 +13,6 @@ type ArgTxBodyInterceptorProcessor struct {
 	Marshalizer      marshal.Marshalizer
 	Hasher           hashing.Hasher
 	ShardCoordinator sharding.Coordinator
+	// BlockBodySink is optional, a nil value disables the notifications
+	BlockBodySink BlockBodySink
 }
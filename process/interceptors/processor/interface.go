@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 )
 
@@ -17,3 +18,10 @@ type InterceptedTransactionHandler interface {
 	TotalValue() *big.Int
 	Transaction() data.TransactionHandler
 }
+
+// BlockBodySink defines an external consumer notified with the miniblocks of each intercepted block body that were
+// newly added to the pool. The provided hash is computed over the provided body
+type BlockBodySink interface {
+	ReceiveBlockBody(hash []byte, body block.Body) error
+	IsInterfaceNil() bool
+}
@@ -21,6 +21,7 @@ type TxBodyInterceptorProcessor struct {
 	marshalizer      marshal.Marshalizer
 	hasher           hashing.Hasher
 	shardCoordinator sharding.Coordinator
+	blockBodySink    BlockBodySink
 }
 
 // NewTxBodyInterceptorProcessor creates a new TxBodyInterceptorProcessor instance
@@ -46,6 +47,7 @@ func NewTxBodyInterceptorProcessor(argument *ArgTxBodyInterceptorProcessor) (*Tx
 		marshalizer:      argument.Marshalizer,
 		hasher:           argument.Hasher,
 		shardCoordinator: argument.ShardCoordinator,
+		blockBodySink:    argument.BlockBodySink,
 	}, nil
 }
 
@@ -65,31 +67,56 @@ func (tbip *TxBodyInterceptorProcessor) Save(data process.InterceptedData) error
 		return process.ErrWrongTypeAssertion
 	}
 
+	addedMiniblocks := make(block.Body, 0)
 	for _, miniblock := range interceptedTxBody.TxBlockBody() {
-		err := tbip.processMiniblock(miniblock)
+		added, err := tbip.processMiniblock(miniblock)
 		if err != nil {
 			return err
 		}
+		if added {
+			addedMiniblocks = append(addedMiniblocks, miniblock)
+		}
+	}
+
+	if len(addedMiniblocks) > 0 {
+		tbip.notifyBlockBodySink(addedMiniblocks)
 	}
 
 	return nil
 }
 
-func (tbip *TxBodyInterceptorProcessor) processMiniblock(miniblock *block.MiniBlock) error {
+func (tbip *TxBodyInterceptorProcessor) processMiniblock(miniblock *block.MiniBlock) (bool, error) {
 	err := tbip.checkMiniblock(miniblock)
 	if err != nil {
 		log.Debug(err.Error())
-		return nil
+		return false, nil
 	}
 
 	hash, err := core.CalculateHash(tbip.marshalizer, tbip.hasher, miniblock)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	tbip.miniblockCache.HasOrAdd(hash, miniblock)
+	found, _ := tbip.miniblockCache.HasOrAdd(hash, miniblock)
 
-	return nil
+	return !found, nil
+}
+
+func (tbip *TxBodyInterceptorProcessor) notifyBlockBodySink(addedMiniblocks block.Body) {
+	if check.IfNil(tbip.blockBodySink) {
+		return
+	}
+
+	hash, err := core.CalculateHash(tbip.marshalizer, tbip.hasher, addedMiniblocks)
+	if err != nil {
+		log.Warn("block body sink: " + err.Error())
+		return
+	}
+
+	err = tbip.blockBodySink.ReceiveBlockBody(hash, addedMiniblocks)
+	if err != nil {
+		log.Warn("block body sink: " + err.Error())
+	}
 }
 
 func (tbip *TxBodyInterceptorProcessor) checkMiniblock(miniblock *block.MiniBlock) error {
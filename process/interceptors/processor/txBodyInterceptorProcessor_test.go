@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/ElrondNetwork/elrond-go/core"
 	"github.com/ElrondNetwork/elrond-go/core/check"
 	"github.com/ElrondNetwork/elrond-go/data/block"
 	"github.com/ElrondNetwork/elrond-go/process"
@@ -292,6 +293,144 @@ func TestTxBodyInterceptorProcessor_SaveMiniblocksMarshalizerFailShouldNotAdd(t
 	assert.Equal(t, errExpected, err)
 }
 
+func TestTxBodyInterceptorProcessor_SaveShouldNotifyBlockBodySink(t *testing.T) {
+	t.Parallel()
+
+	currentShard := uint32(0)
+	txBlockBody := []*block.MiniBlock{
+		{
+			TxHashes:        make([][]byte, 0),
+			ReceiverShardID: currentShard,
+			SenderShardID:   1,
+			Type:            0,
+		},
+	}
+
+	var receivedHash []byte
+	var receivedBody block.Body
+	arg := createMockTxBodyArgument()
+	cacher := arg.MiniblockCache.(*mock.CacherStub)
+	cacher.HasOrAddCalled = func(key []byte, value interface{}) (ok, evicted bool) {
+		return
+	}
+	arg.BlockBodySink = &mock.BlockBodySinkStub{
+		ReceiveBlockBodyCalled: func(hash []byte, body block.Body) error {
+			receivedHash = hash
+			receivedBody = body
+			return errors.New("sink error should not fail the interception")
+		},
+	}
+	tbip, _ := processor.NewTxBodyInterceptorProcessor(arg)
+	inTxBlkBdy := createInteceptedTxBlockBody(txBlockBody)
+
+	err := tbip.Save(inTxBlkBdy)
+
+	assert.Nil(t, err)
+	assert.Equal(t, inTxBlkBdy.Hash(), receivedHash)
+	assert.Equal(t, inTxBlkBdy.TxBlockBody(), receivedBody)
+}
+
+func TestTxBodyInterceptorProcessor_SaveShouldNotifyBlockBodySinkOnlyWithAcceptedMiniblocks(t *testing.T) {
+	t.Parallel()
+
+	currentShard := uint32(0)
+	acceptedMiniblock := &block.MiniBlock{
+		TxHashes:        make([][]byte, 0),
+		ReceiverShardID: currentShard,
+		SenderShardID:   1,
+	}
+	txBlockBody := []*block.MiniBlock{
+		{
+			TxHashes:        make([][]byte, 0),
+			ReceiverShardID: 1,
+			SenderShardID:   2,
+		},
+		acceptedMiniblock,
+	}
+
+	var receivedHash []byte
+	var receivedBody block.Body
+	arg := createMockTxBodyArgument()
+	cacher := arg.MiniblockCache.(*mock.CacherStub)
+	cacher.HasOrAddCalled = func(key []byte, value interface{}) (ok, evicted bool) {
+		return
+	}
+	arg.BlockBodySink = &mock.BlockBodySinkStub{
+		ReceiveBlockBodyCalled: func(hash []byte, body block.Body) error {
+			receivedHash = hash
+			receivedBody = body
+			return nil
+		},
+	}
+	tbip, _ := processor.NewTxBodyInterceptorProcessor(arg)
+	inTxBlkBdy := createInteceptedTxBlockBody(txBlockBody)
+
+	err := tbip.Save(inTxBlkBdy)
+
+	expectedHash, _ := core.CalculateHash(testMarshalizer, testHasher, block.Body{acceptedMiniblock})
+	assert.Nil(t, err)
+	assert.Equal(t, block.Body{acceptedMiniblock}, receivedBody)
+	assert.Equal(t, expectedHash, receivedHash)
+}
+
+func TestTxBodyInterceptorProcessor_SaveAlreadyCachedMiniblocksShouldNotNotifyBlockBodySink(t *testing.T) {
+	t.Parallel()
+
+	currentShard := uint32(0)
+	txBlockBody := []*block.MiniBlock{
+		{
+			TxHashes:        make([][]byte, 0),
+			ReceiverShardID: currentShard,
+			SenderShardID:   1,
+		},
+	}
+
+	arg := createMockTxBodyArgument()
+	cacher := arg.MiniblockCache.(*mock.CacherStub)
+	cacher.HasOrAddCalled = func(key []byte, value interface{}) (ok, evicted bool) {
+		return true, false
+	}
+	arg.BlockBodySink = &mock.BlockBodySinkStub{
+		ReceiveBlockBodyCalled: func(hash []byte, body block.Body) error {
+			assert.Fail(t, "block body sink should have not been called")
+			return nil
+		},
+	}
+	tbip, _ := processor.NewTxBodyInterceptorProcessor(arg)
+	inTxBlkBdy := createInteceptedTxBlockBody(txBlockBody)
+
+	err := tbip.Save(inTxBlkBdy)
+
+	assert.Nil(t, err)
+}
+
+func TestTxBodyInterceptorProcessor_SaveMiniblocksNotForCurrentShardShouldNotNotifyBlockBodySink(t *testing.T) {
+	t.Parallel()
+
+	txBlockBody := []*block.MiniBlock{
+		{
+			TxHashes:        make([][]byte, 0),
+			ReceiverShardID: 1,
+			SenderShardID:   2,
+			Type:            0,
+		},
+	}
+
+	arg := createMockTxBodyArgument()
+	arg.BlockBodySink = &mock.BlockBodySinkStub{
+		ReceiveBlockBodyCalled: func(hash []byte, body block.Body) error {
+			assert.Fail(t, "block body sink should have not been called")
+			return nil
+		},
+	}
+	tbip, _ := processor.NewTxBodyInterceptorProcessor(arg)
+	inTxBlkBdy := createInteceptedTxBlockBody(txBlockBody)
+
+	err := tbip.Save(inTxBlkBdy)
+
+	assert.Nil(t, err)
+}
+
 //------- IsInterfaceNil
 
 func TestTxBodyInterceptorProcessor_IsInterfaceNil(t *testing.T) {
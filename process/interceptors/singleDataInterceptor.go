@@ -13,6 +13,9 @@ type SingleDataInterceptor struct {
 	factory   process.InterceptedDataFactory
 	processor process.InterceptorProcessor
 	throttler process.InterceptorThrottler
+
+	mutSenderRateLimiter sync.RWMutex
+	senderRateLimiter    process.SenderRateLimiter
 }
 
 // NewSingleDataInterceptor hooks a new interceptor for single data
@@ -41,10 +44,28 @@ func NewSingleDataInterceptor(
 	return singleDataIntercept, nil
 }
 
+// SetSenderRateLimiter sets the component used to drop the messages of the senders that send too fast, before
+// the received data is unmarshalled and checked
+func (sdi *SingleDataInterceptor) SetSenderRateLimiter(senderRateLimiter process.SenderRateLimiter) error {
+	if check.IfNil(senderRateLimiter) {
+		return process.ErrNilSenderRateLimiter
+	}
+
+	sdi.mutSenderRateLimiter.Lock()
+	sdi.senderRateLimiter = senderRateLimiter
+	sdi.mutSenderRateLimiter.Unlock()
+
+	return nil
+}
+
 // ProcessReceivedMessage is the callback func from the p2p.Messenger and will be called each time a new message was received
 // (for the topic this validator was registered to)
 func (sdi *SingleDataInterceptor) ProcessReceivedMessage(message p2p.MessageP2P, _ func(buffToSend []byte)) error {
-	err := preProcessMesage(sdi.throttler, message)
+	sdi.mutSenderRateLimiter.RLock()
+	senderRateLimiter := sdi.senderRateLimiter
+	sdi.mutSenderRateLimiter.RUnlock()
+
+	err := preProcessMesage(sdi.throttler, senderRateLimiter, message)
 	if err != nil {
 		return err
 	}
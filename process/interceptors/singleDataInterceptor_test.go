@@ -10,6 +10,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/process"
 	"github.com/ElrondNetwork/elrond-go/process/interceptors"
 	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/ElrondNetwork/elrond-go/process/throttle"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -134,6 +135,58 @@ func TestSingleDataInterceptor_ProcessReceivedMessageFactoryCreationErrorShouldE
 	assert.Equal(t, errExpected, err)
 }
 
+func TestSingleDataInterceptor_SetSenderRateLimiterNilShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sdi, _ := interceptors.NewSingleDataInterceptor(
+		&mock.InterceptedDataFactoryStub{},
+		&mock.InterceptorProcessorStub{},
+		createMockThrottler(),
+	)
+
+	err := sdi.SetSenderRateLimiter(nil)
+
+	assert.Equal(t, process.ErrNilSenderRateLimiter, err)
+}
+
+func TestSingleDataInterceptor_ProcessReceivedMessageSenderRateExceededShouldDropBeforeCreate(t *testing.T) {
+	t.Parallel()
+
+	numCreateCalled := int32(0)
+	sdi, _ := interceptors.NewSingleDataInterceptor(
+		&mock.InterceptedDataFactoryStub{
+			CreateCalled: func(buff []byte) (data process.InterceptedData, e error) {
+				atomic.AddInt32(&numCreateCalled, 1)
+				return nil, errors.New("expected error")
+			},
+		},
+		&mock.InterceptorProcessorStub{},
+		createMockThrottler(),
+	)
+	senderRateLimiter, _ := throttle.NewSenderRateLimiter(0.001, 2)
+	_ = sdi.SetSenderRateLimiter(senderRateLimiter)
+
+	msgFromFlooder := &mock.P2PMessageMock{
+		DataField: []byte("data to be processed"),
+		PeerField: "flooder",
+	}
+	_ = sdi.ProcessReceivedMessage(msgFromFlooder, nil)
+	_ = sdi.ProcessReceivedMessage(msgFromFlooder, nil)
+	err := sdi.ProcessReceivedMessage(msgFromFlooder, nil)
+
+	assert.Equal(t, process.ErrSenderRateLimitExceeded, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&numCreateCalled))
+
+	msgFromOtherPeer := &mock.P2PMessageMock{
+		DataField: []byte("data to be processed"),
+		PeerField: "other peer",
+	}
+	err = sdi.ProcessReceivedMessage(msgFromOtherPeer, nil)
+
+	assert.NotEqual(t, process.ErrSenderRateLimitExceeded, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&numCreateCalled))
+}
+
 func TestSingleDataInterceptor_ProcessReceivedMessageIsNotValidShouldNotCallProcess(t *testing.T) {
 	t.Parallel()
 
@@ -104,6 +104,13 @@ type InterceptorThrottler interface {
 	IsInterfaceNil() bool
 }
 
+// SenderRateLimiter decides if a message received from a sender can be processed or it should be dropped
+// because the sender sends messages too fast
+type SenderRateLimiter interface {
+	Allow(sender p2p.PeerID) bool
+	IsInterfaceNil() bool
+}
+
 // TransactionCoordinator is an interface to coordinate transaction processing using multiple processors
 type TransactionCoordinator interface {
 	RequestMiniBlocks(header data.HeaderHandler)
@@ -0,0 +1,20 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data/block"
+)
+
+type BlockBodySinkStub struct {
+	ReceiveBlockBodyCalled func(hash []byte, body block.Body) error
+}
+
+func (bbss *BlockBodySinkStub) ReceiveBlockBody(hash []byte, body block.Body) error {
+	return bbss.ReceiveBlockBodyCalled(hash, body)
+}
+
+func (bbss *BlockBodySinkStub) IsInterfaceNil() bool {
+	if bbss == nil {
+		return true
+	}
+	return false
+}
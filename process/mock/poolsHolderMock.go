@@ -19,6 +19,7 @@ type PoolsHolderMock struct {
 	miniBlocks           storage.Cacher
 	peerChangesBlocks    storage.Cacher
 	metaHdrNonces        dataRetriever.Uint64SyncMapCacher
+	validatorInfo        storage.Cacher
 }
 
 func NewPoolsHolderMock() *PoolsHolderMock {
@@ -40,6 +41,7 @@ func NewPoolsHolderMock() *PoolsHolderMock {
 	)
 	phf.miniBlocks, _ = storageUnit.NewCache(storageUnit.LRUCache, 10000, 1)
 	phf.peerChangesBlocks, _ = storageUnit.NewCache(storageUnit.LRUCache, 10000, 1)
+	phf.validatorInfo, _ = storageUnit.NewCache(storageUnit.LRUCache, 10000, 1)
 	return phf
 }
 
@@ -79,6 +81,10 @@ func (phm *PoolsHolderMock) MetaHeadersNonces() dataRetriever.Uint64SyncMapCache
 	return phm.metaHdrNonces
 }
 
+func (phm *PoolsHolderMock) ValidatorInfo() storage.Cacher {
+	return phm.validatorInfo
+}
+
 func (phm *PoolsHolderMock) SetTransactions(transactions dataRetriever.ShardedDataCacherNotifier) {
 	phm.transactions = transactions
 }
@@ -14,6 +14,7 @@ type PoolsHolderStub struct {
 	RewardTransactionsCalled   func() dataRetriever.ShardedDataCacherNotifier
 	MiniBlocksCalled           func() storage.Cacher
 	MetaBlocksCalled           func() storage.Cacher
+	ValidatorInfoCalled        func() storage.Cacher
 }
 
 func (phs *PoolsHolderStub) Headers() storage.Cacher {
@@ -48,6 +49,10 @@ func (phs *PoolsHolderStub) RewardTransactions() dataRetriever.ShardedDataCacher
 	return phs.RewardTransactionsCalled()
 }
 
+func (phs *PoolsHolderStub) ValidatorInfo() storage.Cacher {
+	return phs.ValidatorInfoCalled()
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (phs *PoolsHolderStub) IsInterfaceNil() bool {
 	if phs == nil {
@@ -0,0 +1,20 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/p2p"
+)
+
+type SenderRateLimiterStub struct {
+	AllowCalled func(sender p2p.PeerID) bool
+}
+
+func (srls *SenderRateLimiterStub) Allow(sender p2p.PeerID) bool {
+	return srls.AllowCalled(sender)
+}
+
+func (srls *SenderRateLimiterStub) IsInterfaceNil() bool {
+	if srls == nil {
+		return true
+	}
+	return false
+}
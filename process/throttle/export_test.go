@@ -1,5 +1,9 @@
 package throttle
 
+import (
+	"time"
+)
+
 func (bst *blockSizeThrottle) SetMaxItems(maxItems uint32) {
 	bst.maxItems = maxItems
 }
@@ -102,3 +106,20 @@ func (bst *blockSizeThrottle) SetSucceed(round uint64, succeed bool) {
 	}
 	bst.mutThrottler.Unlock()
 }
+
+func (srl *senderRateLimiter) SetGetTimeHandler(handler func() time.Time) {
+	srl.mutBuckets.Lock()
+	srl.getTime = handler
+	srl.mutBuckets.Unlock()
+}
+
+func (srl *senderRateLimiter) NumTrackedSenders() int {
+	srl.mutBuckets.Lock()
+	defer srl.mutBuckets.Unlock()
+
+	return srl.buckets.Len()
+}
+
+func MaxTrackedSenders() int {
+	return maxTrackedSenders
+}
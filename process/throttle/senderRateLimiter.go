@@ -0,0 +1,104 @@
+package throttle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go/p2p"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
+)
+
+// maxTrackedSenders bounds the number of senders for which a token bucket is kept. When exceeded, the bucket of
+// the least recently seen sender is discarded
+const maxTrackedSenders = 10000
+
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// senderRateLimiter implements SenderRateLimiter interface using a token bucket for each sender
+type senderRateLimiter struct {
+	ratePerSecond float64
+	burst         float64
+	mutBuckets    sync.Mutex
+	buckets       *lrucache.LRUCache
+	getTime       func() time.Time
+}
+
+// NewSenderRateLimiter creates a new senderRateLimiter object allowing each sender to send at most burst messages
+// at once and ratePerSecond messages per second on average
+func NewSenderRateLimiter(ratePerSecond float64, burst uint32) (*senderRateLimiter, error) {
+	if ratePerSecond <= 0 || burst == 0 {
+		return nil, process.ErrInvalidSenderRateLimit
+	}
+
+	buckets, err := lrucache.NewCache(maxTrackedSenders)
+	if err != nil {
+		return nil, err
+	}
+
+	return &senderRateLimiter{
+		ratePerSecond: ratePerSecond,
+		burst:         float64(burst),
+		buckets:       buckets,
+		getTime:       time.Now,
+	}, nil
+}
+
+// Allow consumes a token from the bucket of the provided sender and returns false if there was none left
+func (srl *senderRateLimiter) Allow(sender p2p.PeerID) bool {
+	srl.mutBuckets.Lock()
+	defer srl.mutBuckets.Unlock()
+
+	crtTime := srl.getTime()
+	bucket := srl.getOrCreateBucket(sender, crtTime)
+
+	srl.refill(bucket, crtTime)
+	if bucket.tokens < 1 {
+		return false
+	}
+
+	bucket.tokens--
+	return true
+}
+
+func (srl *senderRateLimiter) refill(bucket *tokenBucket, crtTime time.Time) {
+	elapsed := crtTime.Sub(bucket.lastRefill)
+	if elapsed <= 0 {
+		return
+	}
+
+	bucket.tokens += elapsed.Seconds() * srl.ratePerSecond
+	if bucket.tokens > srl.burst {
+		bucket.tokens = srl.burst
+	}
+	bucket.lastRefill = crtTime
+}
+
+func (srl *senderRateLimiter) getOrCreateBucket(sender p2p.PeerID, crtTime time.Time) *tokenBucket {
+	value, ok := srl.buckets.Get([]byte(sender))
+	if ok {
+		bucket, isBucket := value.(*tokenBucket)
+		if isBucket {
+			return bucket
+		}
+	}
+
+	bucket := &tokenBucket{
+		tokens:     srl.burst,
+		lastRefill: crtTime,
+	}
+	_ = srl.buckets.Put([]byte(sender), bucket)
+
+	return bucket
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (srl *senderRateLimiter) IsInterfaceNil() bool {
+	if srl == nil {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package throttle_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go/p2p"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/throttle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSenderRateLimiter_InvalidRateShouldErr(t *testing.T) {
+	t.Parallel()
+
+	srl, err := throttle.NewSenderRateLimiter(0, 1)
+
+	assert.Nil(t, srl)
+	assert.Equal(t, process.ErrInvalidSenderRateLimit, err)
+}
+
+func TestNewSenderRateLimiter_InvalidBurstShouldErr(t *testing.T) {
+	t.Parallel()
+
+	srl, err := throttle.NewSenderRateLimiter(1, 0)
+
+	assert.Nil(t, srl)
+	assert.Equal(t, process.ErrInvalidSenderRateLimit, err)
+}
+
+func TestNewSenderRateLimiter_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	srl, err := throttle.NewSenderRateLimiter(1, 1)
+
+	assert.NotNil(t, srl)
+	assert.Nil(t, err)
+	assert.False(t, srl.IsInterfaceNil())
+}
+
+func TestSenderRateLimiter_AllowExceedingBurstShouldDrop(t *testing.T) {
+	t.Parallel()
+
+	crtTime := time.Unix(1000, 0)
+	srl, _ := throttle.NewSenderRateLimiter(1, 3)
+	srl.SetGetTimeHandler(func() time.Time {
+		return crtTime
+	})
+
+	assert.True(t, srl.Allow("sender"))
+	assert.True(t, srl.Allow("sender"))
+	assert.True(t, srl.Allow("sender"))
+	assert.False(t, srl.Allow("sender"))
+	assert.True(t, srl.Allow("other sender"))
+}
+
+func TestSenderRateLimiter_AllowShouldRefillWithTime(t *testing.T) {
+	t.Parallel()
+
+	crtTime := time.Unix(1000, 0)
+	srl, _ := throttle.NewSenderRateLimiter(2, 2)
+	srl.SetGetTimeHandler(func() time.Time {
+		return crtTime
+	})
+
+	assert.True(t, srl.Allow("sender"))
+	assert.True(t, srl.Allow("sender"))
+	assert.False(t, srl.Allow("sender"))
+
+	crtTime = crtTime.Add(500 * time.Millisecond)
+	assert.True(t, srl.Allow("sender"))
+	assert.False(t, srl.Allow("sender"))
+
+	crtTime = crtTime.Add(time.Hour)
+	assert.True(t, srl.Allow("sender"))
+	assert.True(t, srl.Allow("sender"))
+	assert.False(t, srl.Allow("sender"))
+}
+
+func TestSenderRateLimiter_TooManySendersShouldNotExceedMaxTrackedSenders(t *testing.T) {
+	t.Parallel()
+
+	srl, _ := throttle.NewSenderRateLimiter(1, 1)
+
+	for i := 0; i < 2*throttle.MaxTrackedSenders(); i++ {
+		_ = srl.Allow(p2p.PeerID(fmt.Sprintf("sender%d", i)))
+	}
+
+	assert.Equal(t, throttle.MaxTrackedSenders(), srl.NumTrackedSenders())
+}
+
+func TestSenderRateLimiter_TooManySendersShouldKeepRecentlySeenSenders(t *testing.T) {
+	t.Parallel()
+
+	crtTime := time.Unix(1000, 0)
+	srl, _ := throttle.NewSenderRateLimiter(1, 1)
+	srl.SetGetTimeHandler(func() time.Time {
+		return crtTime
+	})
+
+	assert.True(t, srl.Allow("sender"))
+	for i := 0; i < throttle.MaxTrackedSenders()-1; i++ {
+		_ = srl.Allow(p2p.PeerID(fmt.Sprintf("other sender%d", i)))
+	}
+	assert.False(t, srl.Allow("sender"))
+
+	_ = srl.Allow("new sender")
+
+	assert.False(t, srl.Allow("sender"))
+}